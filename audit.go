@@ -0,0 +1,34 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Audit hook : called with the full context once the response is written.
+//
+// created          16-10-2026
+
+package gorip
+
+// Registers a function called once every response is written, health checks excepted, with the result as written,
+// e.g. to record who did what : values set on ctx.Context by an authentication middleware, ctx.Method, ctx.Route and result.HttpStatus
+// Requests rejected before reaching a handler, e.g. on an invalid query parameter, a replayed nonce or by the IP allowlist,
+// are audited too, with a result holding only the status ; ctx.Route is empty for requests rejected before routing
+func (s *Server) SetAuditHook(hook func(ctx *ResourceHandlerContext, result *ResourceHandlerResult)) {
+	s.auditHook = hook
+}
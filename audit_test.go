@@ -0,0 +1,108 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Audit hook tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAuditHook(t *testing.T) {
+
+	type audited struct {
+		route  string
+		status int
+	}
+	var entries []audited
+
+	s := NewServer(`/`, `:0`)
+	s.SetAuditHook(func(ctx *ResourceHandlerContext, result *ResourceHandlerResult) {
+		entries = append(entries, audited{route: ctx.Route, status: result.HttpStatus})
+	})
+	s.EnableHealthEndpoint(`/health`)
+	s.SetMaxBodySize(8, false)
+	s.EnableNonceProtection(`X-Nonce`, time.Minute, nil)
+
+	handler := textResourceHandler(`POST`, `created`)
+	handler.ContentTypeIn = []string{`text/plain`}
+	handler.QueryParameters = map[string]QueryParameter{`page`: {Kind: QueryParameterInt, DefaultValue: `1`}}
+	s.NewEndpoint(`/items`, handler)
+
+	tests := []struct {
+		name    string
+		target  string
+		body    string
+		header  map[string]string
+		audited []audited
+	}{
+		{`handled`, `/items`, `item`, map[string]string{`X-Nonce`: `1`}, []audited{{`/items`, http.StatusOK}}},
+		{`invalid query parameter`, `/items?page=x`, `item`, map[string]string{`X-Nonce`: `2`}, []audited{{`/items`, http.StatusBadRequest}}},
+		{`body too large`, `/items`, `a large item`, map[string]string{`X-Nonce`: `3`}, []audited{{`/items`, http.StatusRequestEntityTooLarge}}},
+		{`replayed nonce`, `/items`, `item`, map[string]string{`X-Nonce`: `1`}, []audited{{`/items`, http.StatusConflict}}},
+		{`not found`, `/unknown`, ``, nil, []audited{{``, http.StatusNotFound}}},
+		{`health check`, `/health`, ``, nil, nil},
+	}
+
+	for _, test := range tests {
+		entries = nil
+		header := map[string]string{`Content-Type`: `text/plain`}
+		for name, value := range test.header {
+			header[name] = value
+		}
+		serveTestRequest(s, `POST`, test.target, strings.NewReader(test.body), header)
+
+		if len(entries) != len(test.audited) {
+			t.Errorf(`%s : audited %v, expected %v`, test.name, entries, test.audited)
+			continue
+		}
+		for i := range entries {
+			if entries[i] != test.audited[i] {
+				t.Errorf(`%s : audited %v, expected %v`, test.name, entries[i], test.audited[i])
+			}
+		}
+	}
+}
+
+func TestAuditHookRejectedByIPAllowlist(t *testing.T) {
+
+	statuses := []int{}
+
+	s := NewServer(`/`, `:0`)
+	s.SetAuditHook(func(ctx *ResourceHandlerContext, result *ResourceHandlerResult) {
+		statuses = append(statuses, result.HttpStatus)
+	})
+	s.SetIPAllowlist([]string{`10.0.0.0/8`})
+	s.NewEndpoint(`/items`, textResourceHandler(`GET`, `items`))
+
+	// httptest requests come from 192.0.2.1
+	serveTestRequest(s, `GET`, `/items`, nil, nil)
+
+	if len(statuses) != 1 || statuses[0] != http.StatusForbidden {
+		t.Errorf(`audited %v, expected a 403`, statuses)
+	}
+}
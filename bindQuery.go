@@ -0,0 +1,107 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Binds the query parameters of a request into a struct, by gorip field tags.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+const (
+	const_bind_query_tag = `gorip`
+)
+
+// Sets the fields of the struct v points to from the query parameters, e.g. for
+//
+//	type Filter struct {
+//		Page  int    `gorip:"page"`
+//		Sort  string `gorip:"sort"`
+//	}
+//
+// Fields without a tag, or whose parameter has no value, are left as is
+// A field not matching the kind of its declared QueryParameter is an error of the handler, a value that can not be converted a 400 *HTTPError
+func (c *ResourceHandlerContext) BindQuery(v interface{}) error {
+
+	pointer := reflect.ValueOf(v)
+	if pointer.Kind() != reflect.Ptr || pointer.IsNil() || pointer.Elem().Kind() != reflect.Struct {
+		return errors.New(`Query parameters can only be bound into a non nil pointer to a struct`)
+	}
+
+	value := pointer.Elem()
+	for i := 0; i < value.NumField(); i++ {
+
+		structField := value.Type().Field(i)
+		name := strings.Split(structField.Tag.Get(const_bind_query_tag), `,`)[0]
+		if structField.PkgPath != `` || name == `` || name == `-` {
+			continue
+		}
+
+		if declared, ok := c.queryParameters[name]; ok && !isBindableKind(declared.Kind, structField.Type.Kind()) {
+			return errors.New(fmt.Sprintf(`Query parameter %s of kind %s can not be bound to field %s of type %s`, name, declared.Kind, structField.Name, structField.Type.String()))
+		}
+
+		queryValue, ok := c.QueryParameters[name]
+		if !ok || queryValue == `` {
+			continue
+		}
+
+		err := setFormValue(value.Field(i), queryValue)
+		if err != nil {
+			return NewHTTPError(http.StatusBadRequest, fmt.Sprintf(`Invalid query parameter %s : %s`, name, err.Error()))
+		}
+	}
+
+	return nil
+}
+
+// True if a value of the query parameter kind converts to the field kind
+func isBindableKind(queryParameterKind string, fieldKind reflect.Kind) bool {
+
+	switch queryParameterKind {
+
+	case QueryParameterInt:
+		switch fieldKind {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64, reflect.String:
+			return true
+		}
+
+	case QueryParameterFloat:
+		return fieldKind == reflect.Float32 || fieldKind == reflect.Float64 || fieldKind == reflect.String
+
+	case QueryParameterBool:
+		return fieldKind == reflect.Bool || fieldKind == reflect.String
+
+	case QueryParameterString:
+		return fieldKind == reflect.String
+	}
+
+	return false
+}
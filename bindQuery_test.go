@@ -0,0 +1,108 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Query parameters binding tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+type bindQueryTestFilter struct {
+	Page   int    `gorip:"page"`
+	Limit  uint   `gorip:"limit"`
+	Sort   string `gorip:"sort"`
+	Cursor string
+}
+
+func TestBindQuery(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+		body   string
+	}{
+		{`defaults`, ``, http.StatusOK, `{Page:1 Limit:20 Sort: Cursor:kept}`},
+		{`all given`, `?page=3&limit=50&sort=name`, http.StatusOK, `{Page:3 Limit:50 Sort:name Cursor:kept}`},
+		{`untagged field`, `?Cursor=abc&page=2`, http.StatusOK, `{Page:2 Limit:20 Sort: Cursor:kept}`},
+		{`invalid value`, `?page=abc`, http.StatusBadRequest, ``},
+		{`not convertible`, `?limit=-1`, http.StatusBadRequest, `Invalid query parameter limit : strconv.ParseUint: parsing "-1": invalid syntax`},
+	}
+
+	s := NewServer(`/`, `:0`)
+	s.NewEndpoint(`/items`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/plain`}, QueryParameters: map[string]QueryParameter{
+		`page`:  {Kind: QueryParameterInt, DefaultValue: `1`},
+		`limit`: {Kind: QueryParameterInt, DefaultValue: `20`},
+		`sort`:  {Kind: QueryParameterString},
+	}, Implementation: ErrorHandlerFunc(func(context *ResourceHandlerContext) (ResourceHandlerResult, error) {
+		filter := bindQueryTestFilter{Cursor: `kept`}
+		if err := context.BindQuery(&filter); err != nil {
+			return ResourceHandlerResult{}, err
+		}
+		return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(fmt.Sprintf(`%+v`, filter))}, nil
+	})})
+
+	for _, test := range tests {
+		recorder := serveTestRequest(s, `GET`, `/items`+test.query, nil, nil)
+		if recorder.Code != test.status || (test.body != `` && recorder.Body.String() != test.body) {
+			t.Errorf(`%s : got %d %q, expected %d %q`, test.name, recorder.Code, recorder.Body.String(), test.status, test.body)
+		}
+	}
+}
+
+func TestBindQueryErrors(t *testing.T) {
+
+	type mismatchedFilter struct {
+		Page bool `gorip:"page"`
+	}
+
+	tests := []struct {
+		name string
+		v    interface{}
+		err  string
+	}{
+		{`not a pointer`, bindQueryTestFilter{}, `Query parameters can only be bound into a non nil pointer to a struct`},
+		{`not a struct`, new(string), `Query parameters can only be bound into a non nil pointer to a struct`},
+		{`kind mismatch`, &mismatchedFilter{}, `Query parameter page of kind int can not be bound to field Page of type bool`},
+	}
+
+	for _, test := range tests {
+		var err error
+
+		s := NewServer(`/`, `:0`)
+		s.NewEndpoint(`/items`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/plain`}, QueryParameters: map[string]QueryParameter{`page`: {Kind: QueryParameterInt}}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			err = context.BindQuery(test.v)
+			return ResourceHandlerResult{HttpStatus: http.StatusNoContent}
+		})})
+
+		serveTestRequest(s, `GET`, `/items?page=1`, nil, nil)
+		if err == nil || err.Error() != test.err {
+			t.Errorf(`%s : expected %q, got %v`, test.name, test.err, err)
+		}
+	}
+}
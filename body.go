@@ -0,0 +1,122 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Request body buffering : in memory, or in a temporary file for large bodies.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+const (
+	const_body_spill_file_prefix = `gorip-body-`
+)
+
+// Request bodies larger than threshold bytes are written to a temporary file instead of memory,
+// handlers then read them through ResourceHandlerContext.BodyReader, zero disables spilling
+func (s *Server) SetBodySpillThreshold(threshold int64) {
+	s.bodySpillThreshold = threshold
+}
+
+// Reads a request body in memory, or in a temporary file once it exceeds spillThreshold ( if > 0 )
+// The caller must release a returned file with releaseSpilledBody
+func readRequestBody(reader io.Reader, spillThreshold int64) ([]byte, *os.File, int64, error) {
+
+	if spillThreshold <= 0 {
+		bodyInBytes, err := ioutil.ReadAll(reader)
+		return bodyInBytes, nil, int64(len(bodyInBytes)), err
+	}
+
+	bodyInBytes, err := ioutil.ReadAll(io.LimitReader(reader, spillThreshold+1))
+	if err != nil || int64(len(bodyInBytes)) <= spillThreshold {
+		return bodyInBytes, nil, int64(len(bodyInBytes)), err
+	}
+
+	// Too large : what was read and the rest go to a temporary file
+	file, err := ioutil.TempFile(``, const_body_spill_file_prefix)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	size, err := io.Copy(file, io.MultiReader(bytes.NewReader(bodyInBytes), reader))
+	if err == nil {
+		_, err = file.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		releaseSpilledBody(file)
+		return nil, nil, 0, err
+	}
+
+	return nil, file, size, nil
+}
+
+var errRequestBodyTooLarge = errors.New(`Request body too large`)
+
+// Limits request bodies to maxSize bytes, zero disables the limit
+// A larger body is rejected with a 413, or when truncate is set cut to maxSize bytes with ResourceHandlerContext.BodyTruncated set
+func (s *Server) SetMaxBodySize(maxSize int64, truncate bool) {
+	s.maxBodySize = maxSize
+	s.maxBodyTruncate = truncate
+}
+
+// Reads at most remaining bytes, then tells whether the underlying reader had more
+type maxBodyReader struct {
+	reader    io.Reader
+	remaining int64
+	truncate  bool
+	exceeded  bool
+}
+
+func (r *maxBodyReader) Read(p []byte) (int, error) {
+
+	if r.remaining <= 0 {
+		var probe [1]byte
+		n, _ := io.ReadFull(r.reader, probe[:])
+		if n > 0 {
+			r.exceeded = true
+			if !r.truncate {
+				return 0, errRequestBodyTooLarge
+			}
+		}
+		return 0, io.EOF
+	}
+
+	if int64(len(p)) > r.remaining {
+		p = p[:r.remaining]
+	}
+
+	n, err := r.reader.Read(p)
+	r.remaining -= int64(n)
+
+	return n, err
+}
+
+func releaseSpilledBody(file *os.File) {
+	file.Close()
+	os.Remove(file.Name())
+}
@@ -0,0 +1,189 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Request body tests : buffering, size limits and multipart streaming.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+)
+
+// Multipart body holding a single file part of size bytes
+func newMultipartTestBody(size int) (*bytes.Buffer, string) {
+
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile(`file`, `upload.bin`)
+	part.Write([]byte(strings.Repeat(`z`, size)))
+	writer.Close()
+
+	return body, writer.FormDataContentType()
+}
+
+func TestMultipartReader(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		stream      bool
+		maxBodySize int64
+		status      int
+		buffered    bool
+	}{
+		{`buffered`, false, 0, http.StatusOK, true},
+		{`streamed`, true, 0, http.StatusOK, false},
+		{`streamed within the max body size`, true, 2 << 20, http.StatusOK, false},
+		{`streamed past the max body size`, true, 1 << 10, http.StatusRequestEntityTooLarge, false},
+	}
+
+	for _, test := range tests {
+		var partSize int64
+		var buffered bool
+
+		s := NewServer(`/`, `:0`)
+		s.SetMaxBodySize(test.maxBodySize, false)
+		s.NewEndpoint(`/uploads`, ResourceHandler{Method: `POST`, ContentTypeIn: []string{`multipart/form-data`}, ContentTypeOut: []string{`text/plain`}, StreamBody: test.stream, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			buffered = context.Body.Len() > 0
+			reader, err := context.MultipartReader()
+			if err != nil {
+				return errorResult(err)
+			}
+			part, err := reader.NextPart()
+			if err != nil {
+				return errorResult(err)
+			}
+			partSize, err = io.Copy(ioutil.Discard, part)
+			if err != nil {
+				return errorResult(err)
+			}
+			return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(`uploaded`)}
+		})})
+
+		body, contentType := newMultipartTestBody(1 << 20)
+		// Of unknown length, so that the max body size is enforced while reading
+		recorder := serveTestRequest(s, `POST`, `/uploads`, io.MultiReader(body), map[string]string{`Content-Type`: contentType})
+
+		if recorder.Code != test.status {
+			t.Errorf(`%s : expected %d, got %d %q`, test.name, test.status, recorder.Code, recorder.Body.String())
+		}
+		if test.status == http.StatusOK && partSize != 1<<20 {
+			t.Errorf(`%s : read a part of %d bytes`, test.name, partSize)
+		}
+		if buffered != test.buffered {
+			t.Errorf(`%s : body buffered %t, expected %t`, test.name, buffered, test.buffered)
+		}
+	}
+}
+
+func TestBodySpillThreshold(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		threshold int64
+		size      int
+		spilled   bool
+	}{
+		{`spilling disabled`, 0, 1 << 16, false},
+		{`below the threshold`, 1 << 10, 1 << 10, false},
+		{`above the threshold`, 1 << 10, 1 << 16, true},
+	}
+
+	for _, test := range tests {
+		var spillFile string
+		var bodyLen int
+		var read []byte
+
+		s := NewServer(`/`, `:0`)
+		s.SetBodySpillThreshold(test.threshold)
+		s.NewEndpoint(`/uploads`, ResourceHandler{Method: `POST`, ContentTypeIn: []string{`text/plain`}, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			if file, ok := context.BodyReader.(*os.File); ok {
+				spillFile = file.Name()
+			}
+			bodyLen = context.Body.Len()
+			read, _ = ioutil.ReadAll(context.BodyReader)
+			return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(`uploaded`)}
+		})})
+
+		body := strings.Repeat(`z`, test.size)
+		recorder := serveTestRequest(s, `POST`, `/uploads`, strings.NewReader(body), map[string]string{`Content-Type`: `text/plain`})
+
+		if recorder.Code != http.StatusOK {
+			t.Errorf(`%s : expected 200, got %d %q`, test.name, recorder.Code, recorder.Body.String())
+		}
+		if string(read) != body {
+			t.Errorf(`%s : read %d bytes, expected %d`, test.name, len(read), test.size)
+		}
+		if spilled := spillFile != ``; spilled != test.spilled || spilled == (bodyLen > 0) {
+			t.Errorf(`%s : spilled %t with %d bytes in memory, expected spilled %t`, test.name, spilled, bodyLen, test.spilled)
+		}
+		if _, err := os.Stat(spillFile); spillFile != `` && !os.IsNotExist(err) {
+			t.Errorf(`%s : %s was not removed after the request`, test.name, spillFile)
+		}
+	}
+}
+
+func TestMaxBodySize(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		maxSize   int64
+		truncate  bool
+		body      string
+		status    int
+		read      string
+		truncated bool
+	}{
+		{`no limit`, 0, false, `0123456789`, http.StatusOK, `0123456789`, false},
+		{`within the limit`, 10, false, `0123456789`, http.StatusOK, `0123456789`, false},
+		{`over the limit`, 4, false, `0123456789`, http.StatusRequestEntityTooLarge, ``, false},
+		{`within the limit truncating`, 10, true, `0123456789`, http.StatusOK, `0123456789`, false},
+		{`over the limit truncating`, 4, true, `0123456789`, http.StatusOK, `0123`, true},
+	}
+
+	for _, test := range tests {
+		var read string
+		var truncated bool
+
+		s := NewServer(`/`, `:0`)
+		s.SetMaxBodySize(test.maxSize, test.truncate)
+		s.NewEndpoint(`/uploads`, ResourceHandler{Method: `POST`, ContentTypeIn: []string{`text/plain`}, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			read = context.Body.String()
+			truncated = context.BodyTruncated
+			return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(`uploaded`)}
+		})})
+
+		// Of unknown length, so that the limit is enforced while reading
+		recorder := serveTestRequest(s, `POST`, `/uploads`, io.MultiReader(strings.NewReader(test.body)), map[string]string{`Content-Type`: `text/plain`})
+
+		if recorder.Code != test.status || read != test.read || truncated != test.truncated {
+			t.Errorf(`%s : got %d reading %q truncated %t, expected %d reading %q truncated %t`, test.name, recorder.Code, read, truncated, test.status, test.read, test.truncated)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Declarative Cache-Control directives of a resource handler.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cache-Control directives sent with the successful responses of a resource handler,
+// unless the result sets its own Cache-Control header
+type CacheControl struct {
+	Public         bool
+	Private        bool
+	NoCache        bool
+	NoStore        bool
+	MustRevalidate bool
+	MaxAge         time.Duration // in whole seconds, omitted when 0
+}
+
+// Cache-Control header value, e.g. public, max-age=60
+func (c *CacheControl) String() string {
+
+	directives := []string{}
+
+	if c.Public {
+		directives = append(directives, `public`)
+	}
+	if c.Private {
+		directives = append(directives, `private`)
+	}
+	if c.NoCache {
+		directives = append(directives, `no-cache`)
+	}
+	if c.NoStore {
+		directives = append(directives, `no-store`)
+	}
+	if c.MustRevalidate {
+		directives = append(directives, `must-revalidate`)
+	}
+	if c.MaxAge > 0 {
+		directives = append(directives, `max-age=`+strconv.FormatInt(int64(c.MaxAge/time.Second), 10))
+	}
+
+	return strings.Join(directives, `, `)
+}
+
+// Adds the Cache-Control header of the resource handler to a successful result not carrying one
+func applyCacheControl(resource *ResourceHandler, result ResourceHandlerResult) ResourceHandlerResult {
+
+	if resource.CacheControl == nil || result.HttpStatus < 200 || result.HttpStatus >= 300 || result.Header.Get(`Cache-Control`) != `` {
+		return result
+	}
+
+	value := resource.CacheControl.String()
+	if value == `` {
+		return result
+	}
+
+	return result.withHeader(`Cache-Control`, value)
+}
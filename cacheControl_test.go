@@ -0,0 +1,82 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Cache-Control directives tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCacheControlString(t *testing.T) {
+
+	tests := []struct {
+		cacheControl CacheControl
+		value        string
+	}{
+		{CacheControl{}, ``},
+		{CacheControl{Public: true, MaxAge: time.Minute}, `public, max-age=60`},
+		{CacheControl{Private: true, MustRevalidate: true, MaxAge: 1500 * time.Millisecond}, `private, must-revalidate, max-age=1`},
+		{CacheControl{NoCache: true, NoStore: true}, `no-cache, no-store`},
+	}
+
+	for _, test := range tests {
+		if value := test.cacheControl.String(); value != test.value {
+			t.Errorf(`%+v : got %q, expected %q`, test.cacheControl, value, test.value)
+		}
+	}
+}
+
+func TestResourceHandlerCacheControl(t *testing.T) {
+
+	tests := []struct {
+		name         string
+		cacheControl *CacheControl
+		result       ResourceHandlerResult
+		value        string
+	}{
+		{`declared`, &CacheControl{Public: true, MaxAge: time.Hour}, ResourceHandlerResult{HttpStatus: http.StatusOK}, `public, max-age=3600`},
+		{`not declared`, nil, ResourceHandlerResult{HttpStatus: http.StatusOK}, ``},
+		{`no directive`, &CacheControl{}, ResourceHandlerResult{HttpStatus: http.StatusOK}, ``},
+		{`error result`, &CacheControl{Public: true, MaxAge: time.Hour}, ResourceHandlerResult{HttpStatus: http.StatusNotFound}, ``},
+		{`overridden by the result`, &CacheControl{Public: true, MaxAge: time.Hour}, ResourceHandlerResult{HttpStatus: http.StatusOK, Header: http.Header{`Cache-Control`: {`no-store`}}}, `no-store`},
+	}
+
+	for _, test := range tests {
+		s := NewServer(`/`, `:0`)
+		s.NewEndpoint(`/reports`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/plain`}, CacheControl: test.cacheControl, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			result := test.result
+			result.Body = bytes.NewBufferString(`report`)
+			return result
+		})})
+
+		recorder := serveTestRequest(s, `GET`, `/reports`, nil, nil)
+		if value := recorder.Header().Get(`Cache-Control`); recorder.Code != test.result.HttpStatus || value != test.value {
+			t.Errorf(`%s : got %d with %q, expected %d with %q`, test.name, recorder.Code, value, test.result.HttpStatus, test.value)
+		}
+	}
+}
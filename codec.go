@@ -0,0 +1,176 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Codecs serialize Go values for a given content type.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+)
+
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+type JSONCodec struct {
+	UseNumber       bool // numbers decode into json.Number instead of float64 in interface{} values, keeping large integers exact
+	TrailingNewline bool // encoded values end with a newline, as json.Encoder writes them
+}
+
+func (c *JSONCodec) Marshal(v interface{}) ([]byte, error) {
+
+	encoded, err := json.Marshal(v)
+	if err != nil || !c.TrailingNewline {
+		return encoded, err
+	}
+
+	return append(encoded, '\n'), nil
+}
+
+func (c *JSONCodec) Unmarshal(data []byte, v interface{}) error {
+
+	if !c.UseNumber {
+		return json.Unmarshal(data, v)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	err := decoder.Decode(v)
+	if err != nil {
+		return err
+	}
+
+	// Rejects trailing data as json.Unmarshal does
+	_, err = decoder.Token()
+	if err != io.EOF {
+		return errors.New(`Unexpected data after the JSON value`)
+	}
+
+	return nil
+}
+
+type XMLCodec struct {
+}
+
+func (c *XMLCodec) Marshal(v interface{}) ([]byte, error) {
+	return xml.Marshal(v)
+}
+
+func (c *XMLCodec) Unmarshal(data []byte, v interface{}) error {
+	return xml.Unmarshal(data, v)
+}
+
+// Decodes JSON numbers into json.Number, see JSONCodec.UseNumber, with the JSON codecs registered so far
+func (s *Server) SetJSONUseNumber(b bool) {
+	for _, c := range s.codecs {
+		if jsonCodec, ok := c.(*JSONCodec); ok {
+			jsonCodec.UseNumber = b
+		}
+	}
+}
+
+// Ends encoded JSON results with a newline, see JSONCodec.TrailingNewline, with the JSON codecs registered so far
+func (s *Server) SetJSONTrailingNewline(b bool) {
+	for _, c := range s.codecs {
+		if jsonCodec, ok := c.(*JSONCodec); ok {
+			jsonCodec.TrailingNewline = b
+		}
+	}
+}
+
+// Registers the codec used for the given content type, replacing any previous one
+func (s *Server) RegisterCodec(contentType string, c Codec) {
+	s.codecs[contentType] = c
+}
+
+func (s *Server) registerDefaultCodecs() {
+	for contentType, c := range newDefaultCodecs() {
+		s.RegisterCodec(contentType, c)
+	}
+}
+
+func newDefaultCodecs() map[string]Codec {
+	return map[string]Codec{
+		`application/json`:                  &JSONCodec{},
+		`application/xml`:                   &XMLCodec{},
+		`text/xml`:                          &XMLCodec{},
+		`application/x-www-form-urlencoded`: &FormCodec{},
+	}
+}
+
+// Codec registered for the content type, or else for its structured syntax suffix,
+// e.g. the application/json one for application/vnd.api.v2+json
+func findCodec(codecs map[string]Codec, contentType string) (Codec, bool) {
+
+	codec, ok := codecs[contentType]
+	if ok {
+		return codec, true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, false
+	}
+
+	plus := strings.LastIndex(mediaType, `+`)
+	if plus < 0 {
+		return nil, false
+	}
+
+	codec, ok = codecs[`application/`+mediaType[plus+1:]]
+
+	return codec, ok
+}
+
+// Serializes the result payload into its body using the codec registered for the content type
+// Results already carrying a body are left untouched
+func (s *Server) encodeResultPayload(result *ResourceHandlerResult, contentType string) error {
+
+	if result.Raw || result.Body != nil || result.Payload == nil {
+		return nil
+	}
+
+	codec, ok := findCodec(s.codecs, contentType)
+	if !ok {
+		return errors.New(fmt.Sprintf(`No codec registered for content type %s`, contentType))
+	}
+
+	encoded, err := codec.Marshal(result.Payload)
+	if err != nil {
+		return err
+	}
+
+	result.Body = bytes.NewBuffer(encoded)
+
+	return nil
+}
@@ -0,0 +1,200 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Codec tests : payload serialization through the negotiated content type.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type codecTestUser struct {
+	Name string `json:"name" xml:"name"`
+}
+
+// JSON codec counting its encodings
+type countingCodec struct {
+	JSONCodec
+	marshals int
+}
+
+func (c *countingCodec) Marshal(v interface{}) ([]byte, error) {
+	c.marshals++
+	return c.JSONCodec.Marshal(v)
+}
+
+func TestResultPayloadSerialization(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		accept      string
+		status      int
+		contentType string
+		body        string
+	}{
+		{`json`, `application/json`, http.StatusOK, `application/json`, `{"name":"bob"}`},
+		{`xml`, `application/xml`, http.StatusOK, `application/xml`, `<codecTestUser><name>bob</name></codecTestUser>`},
+		{`any`, `*/*`, http.StatusOK, `application/json`, `{"name":"bob"}`},
+		{`no codec`, `text/csv`, http.StatusInternalServerError, `text/plain`, `Could not serialize the response as text/csv`},
+	}
+
+	for _, test := range tests {
+		s := NewServer(`/`, `:0`)
+		s.NewEndpoint(`/users/bob`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`application/json`, `application/xml`, `text/csv`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			return NewResult(http.StatusOK, codecTestUser{Name: `bob`})
+		})})
+
+		recorder := serveTestRequest(s, `GET`, `/users/bob`, nil, map[string]string{`Accept`: test.accept})
+
+		if recorder.Code != test.status {
+			t.Errorf(`%s : expected %d, got %d`, test.name, test.status, recorder.Code)
+		}
+		if recorder.Header().Get(`Content-Type`) != test.contentType {
+			t.Errorf(`%s : Content-Type %q, expected %q`, test.name, recorder.Header().Get(`Content-Type`), test.contentType)
+		}
+		if recorder.Body.String() != test.body {
+			t.Errorf(`%s : body %q, expected %q`, test.name, recorder.Body.String(), test.body)
+		}
+	}
+}
+
+func TestResultPayloadIsSerializedOnce(t *testing.T) {
+
+	codec := &countingCodec{}
+
+	s := NewServer(`/`, `:0`)
+	s.RegisterCodec(`application/json`, codec)
+	s.NewEndpoint(`/users/bob`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`application/json`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+		return NewResult(http.StatusOK, codecTestUser{Name: `bob`})
+	})})
+
+	if recorder := serveTestRequest(s, `GET`, `/users/bob`, nil, nil); recorder.Code != http.StatusOK {
+		t.Errorf(`expected 200, got %d`, recorder.Code)
+	}
+	if codec.marshals != 1 {
+		t.Errorf(`payload serialized %d times, expected once`, codec.marshals)
+	}
+}
+
+func TestEncodeResultPayload(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		result      ResourceHandlerResult
+		contentType string
+		body        string
+		failed      bool
+	}{
+		{`json`, NewResult(http.StatusOK, codecTestUser{Name: `bob`}), `application/json`, `{"name":"bob"}`, false},
+		{`xml`, NewResult(http.StatusOK, codecTestUser{Name: `bob`}), `application/xml`, `<codecTestUser><name>bob</name></codecTestUser>`, false},
+		{`body kept`, ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(`bob`), Payload: codecTestUser{Name: `alice`}}, `application/json`, `bob`, false},
+		{`no payload`, ResourceHandlerResult{HttpStatus: http.StatusNoContent}, `application/json`, ``, false},
+		{`no codec`, NewResult(http.StatusOK, codecTestUser{Name: `bob`}), `text/csv`, ``, true},
+	}
+
+	s := NewServer(`/`, `:0`)
+
+	for _, test := range tests {
+		result := test.result
+		err := s.encodeResultPayload(&result, test.contentType)
+
+		if failed := err != nil; failed != test.failed {
+			t.Errorf(`%s : failed %t, expected %t`, test.name, failed, test.failed)
+		}
+		body := ``
+		if result.Body != nil {
+			body = result.Body.String()
+		}
+		if body != test.body {
+			t.Errorf(`%s : body %q, expected %q`, test.name, body, test.body)
+		}
+	}
+}
+
+func TestJSONUseNumber(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		useNumber bool
+		body      string
+		status    int
+		id        string
+	}{
+		{`float64`, false, `{"id":9007199254740993}`, http.StatusOK, `float64 9.007199254740992e+15`},
+		{`json.Number`, true, `{"id":9007199254740993}`, http.StatusOK, `json.Number 9007199254740993`},
+		{`trailing data`, true, `{"id":1} {"id":2}`, http.StatusBadRequest, ``},
+		{`malformed`, true, `{"id":`, http.StatusBadRequest, ``},
+	}
+
+	for _, test := range tests {
+		var id string
+
+		s := NewServer(`/`, `:0`)
+		s.SetJSONUseNumber(test.useNumber)
+		s.NewEndpoint(`/items`, ResourceHandler{Method: `POST`, ContentTypeIn: []string{`application/json`}, ContentTypeOut: []string{`text/plain`}, Implementation: ErrorHandlerFunc(func(context *ResourceHandlerContext) (ResourceHandlerResult, error) {
+			var item map[string]interface{}
+			if err := context.Decode(&item); err != nil {
+				return ResourceHandlerResult{}, err
+			}
+			id = fmt.Sprintf(`%T %v`, item[`id`], item[`id`])
+			return ResourceHandlerResult{HttpStatus: http.StatusOK}, nil
+		})})
+
+		recorder := serveTestRequest(s, `POST`, `/items`, strings.NewReader(test.body), map[string]string{`Content-Type`: `application/json`})
+		if recorder.Code != test.status || id != test.id {
+			t.Errorf(`%s : got %d decoding %q, expected %d decoding %q`, test.name, recorder.Code, id, test.status, test.id)
+		}
+	}
+}
+
+func TestJSONTrailingNewline(t *testing.T) {
+
+	tests := []struct {
+		name            string
+		trailingNewline bool
+		accept          string
+		body            string
+	}{
+		{`default`, false, `application/json`, `{"name":"bob"}`},
+		{`trailing newline`, true, `application/json`, "{\"name\":\"bob\"}\n"},
+		{`other codecs unchanged`, true, `application/xml`, `<codecTestUser><name>bob</name></codecTestUser>`},
+	}
+
+	for _, test := range tests {
+		s := NewServer(`/`, `:0`)
+		s.SetJSONTrailingNewline(test.trailingNewline)
+		s.NewEndpoint(`/users/bob`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`application/json`, `application/xml`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			return NewResult(http.StatusOK, codecTestUser{Name: `bob`})
+		})})
+
+		recorder := serveTestRequest(s, `GET`, `/users/bob`, nil, map[string]string{`Accept`: test.accept})
+		if recorder.Code != http.StatusOK || recorder.Body.String() != test.body {
+			t.Errorf(`%s : got %d %q, expected %q`, test.name, recorder.Code, recorder.Body.String(), test.body)
+		}
+	}
+}
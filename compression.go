@@ -0,0 +1,170 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Gzip compression of responses, restricted to compressible content types.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Compressed by default, already compressed formats such as images or archives are left as is
+var defaultCompressibleContentTypes = []string{`text/*`, `application/json`, `application/xml`, `application/javascript`, `application/problem+json`}
+
+// Compresses responses with gzip when the client accepts it and the Content-Type is compressible
+func (s *Server) EnableResponseCompression(b bool) {
+	s.responseCompressionEnabled = b
+}
+
+// Replaces the compressible content types, an entry ending with /* matches a whole type, e.g. text/*
+func (s *Server) SetCompressibleContentTypes(contentTypes []string) {
+	s.compressibleContentTypes = contentTypes
+}
+
+func (s *Server) isCompressibleContentType(contentType string) bool {
+
+	contentType = strings.TrimSpace(strings.Split(contentType, `;`)[0])
+	if contentType == `` {
+		return false
+	}
+
+	compressibleContentTypes := s.compressibleContentTypes
+	if compressibleContentTypes == nil {
+		compressibleContentTypes = defaultCompressibleContentTypes
+	}
+
+	for _, compressible := range compressibleContentTypes {
+		if strings.HasSuffix(compressible, `/*`) {
+			if strings.HasPrefix(contentType, strings.TrimSuffix(compressible, `*`)) {
+				return true
+			}
+		} else if compressible == contentType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Whether gzip, or * standing for any other coding, is listed with a non zero q-value, e.g. not gzip;q=0
+func acceptsGzip(request *http.Request) bool {
+
+	priorities := make(map[string]float64)
+	for _, element := range strings.Split(request.Header.Get(`Accept-Encoding`), `,`) {
+
+		parts := strings.Split(element, `;`)
+		encoding := strings.ToLower(strings.TrimSpace(parts[0]))
+		if encoding == `` {
+			continue
+		}
+
+		priority := 1.0
+		for _, param := range parts[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, `q=`) {
+				q, err := strconv.ParseFloat(strings.TrimPrefix(param, `q=`), 64)
+				if err == nil {
+					priority = q
+				}
+			}
+		}
+
+		priorities[encoding] = priority
+	}
+
+	priority, ok := priorities[`gzip`]
+	if !ok {
+		priority = priorities[`*`]
+	}
+
+	return priority > 0
+}
+
+// Decides to compress once the headers are written, when the Content-Type is known
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	compressible func(contentType string) bool
+	gzipWriter   *gzip.Writer
+	wroteHeader  bool
+}
+
+func newGzipResponseWriter(writer http.ResponseWriter, compressible func(contentType string) bool) *gzipResponseWriter {
+	return &gzipResponseWriter{ResponseWriter: writer, compressible: compressible}
+}
+
+func (w *gzipResponseWriter) WriteHeader(httpStatus int) {
+
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	header := w.ResponseWriter.Header()
+	if httpStatus != http.StatusNoContent && httpStatus != http.StatusNotModified && header.Get(`Content-Encoding`) == `` && w.compressible(header.Get(`Content-Type`)) {
+		header.Del(`Content-Length`)
+		header.Set(`Content-Encoding`, `gzip`)
+		header.Add(`Vary`, `Accept-Encoding`)
+		w.gzipWriter = gzip.NewWriter(w.ResponseWriter)
+	}
+
+	w.ResponseWriter.WriteHeader(httpStatus)
+}
+
+func (w *gzipResponseWriter) Write(p []byte) (int, error) {
+
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.gzipWriter != nil {
+		return w.gzipWriter.Write(p)
+	}
+
+	return w.ResponseWriter.Write(p)
+}
+
+func (w *gzipResponseWriter) Flush() {
+
+	if w.gzipWriter != nil {
+		w.gzipWriter.Flush()
+	}
+
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *gzipResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Writes the end of the gzip stream, if the response was compressed
+func (w *gzipResponseWriter) Close() {
+	if w.gzipWriter != nil {
+		w.gzipWriter.Close()
+	}
+}
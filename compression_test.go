@@ -0,0 +1,84 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Response compression tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestResponseCompression(t *testing.T) {
+
+	body := strings.Repeat(`a`, 1000)
+
+	tests := []struct {
+		name           string
+		contentType    string
+		acceptEncoding string
+		compressed     bool
+	}{
+		{`gzip`, `application/json`, `gzip`, true},
+		{`gzip among others`, `application/json`, `deflate, GZIP;q=0.5`, true},
+		{`any coding`, `application/json`, `*`, true},
+		{`gzip refused`, `application/json`, `gzip;q=0`, false},
+		{`gzip refused over any coding`, `application/json`, `*, gzip; q=0`, false},
+		{`any coding refused`, `application/json`, `br, *;q=0`, false},
+		{`no Accept-Encoding`, `application/json`, ``, false},
+		{`already compressed content type`, `image/jpeg`, `gzip`, false},
+	}
+
+	for _, test := range tests {
+		s := NewServer(`/`, `:0`)
+		s.EnableResponseCompression(true)
+		s.NewEndpoint(`/document`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{test.contentType}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(body)}
+		})})
+
+		recorder := serveTestRequest(s, `GET`, `/document`, nil, map[string]string{`Accept-Encoding`: test.acceptEncoding})
+
+		if compressed := recorder.Header().Get(`Content-Encoding`) == `gzip`; compressed != test.compressed {
+			t.Errorf(`%s : compressed %t, expected %t`, test.name, compressed, test.compressed)
+			continue
+		}
+
+		received := recorder.Body.Bytes()
+		if test.compressed {
+			reader, err := gzip.NewReader(recorder.Body)
+			if err != nil {
+				t.Errorf(`%s : %s`, test.name, err.Error())
+				continue
+			}
+			received, _ = ioutil.ReadAll(reader)
+		}
+		if string(received) != body {
+			t.Errorf(`%s : received %d bytes, expected %d`, test.name, len(received), len(body))
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Caps the number of write requests executed at once.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Executes at most n POST, PUT, PATCH and DELETE handlers at once, 0 for no limit ; other methods are not limited
+// Further write requests get a 503 with a Retry-After header, at once or after waiting, see SetWriteConcurrencyMaxWait
+// Meant to be set before serving
+func (s *Server) SetWriteConcurrencyLimit(n int) {
+
+	if n <= 0 {
+		s.writeSlots = nil
+		return
+	}
+
+	s.writeSlots = make(chan struct{}, n)
+}
+
+// Lets a write request wait up to maxWait for a slot while its context is alive, see SetRequestDeadline
+// Zero, the default, rejects it at once so that a saturated server does not pile up waiting requests
+// Meant to be set before serving
+func (s *Server) SetWriteConcurrencyMaxWait(maxWait time.Duration) {
+	s.writeSlotMaxWait = maxWait
+}
+
+func isWriteMethod(method string) bool {
+	return method == `POST` || method == `PUT` || method == `PATCH` || method == `DELETE`
+}
+
+// Takes a free slot, or waits for one up to the max wait, renders a 503 and returns false if none frees up
+func (s *Server) acquireWriteSlot(writer http.ResponseWriter, slots chan struct{}, ctx context.Context, requestId string) bool {
+
+	select {
+	case slots <- struct{}{}:
+		return true
+	default:
+	}
+
+	if s.writeSlotMaxWait > 0 {
+		timer := time.NewTimer(s.writeSlotMaxWait)
+		defer timer.Stop()
+
+		select {
+		case slots <- struct{}{}:
+			return true
+		case <-ctx.Done():
+		case <-timer.C:
+		}
+	}
+
+	// Clients retry once a waiting request would have given up, at least a second later
+	retryAfter := s.writeSlotMaxWait
+	if retryAfter < time.Second {
+		retryAfter = time.Second
+	}
+
+	message := fmt.Sprintf("Too many concurrent write requests")
+	Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Too many concurrent write requests", requestId))
+	result := ResourceHandlerResult{HttpStatus: http.StatusServiceUnavailable, Body: bytes.NewBufferString(message)}.WithRetryAfter(retryAfter)
+	s.renderResourceResult(writer, &result, `text/plain`, requestId)
+
+	return false
+}
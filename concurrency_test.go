@@ -0,0 +1,122 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Write concurrency limit tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWriteConcurrencyLimit(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		method     string
+		maxWait    time.Duration
+		status     int
+		retryAfter string
+	}{
+		{`read`, `GET`, 0, http.StatusOK, ``},
+		{`write rejected at once`, `POST`, 0, http.StatusServiceUnavailable, `1`},
+		{`write rejected after waiting`, `POST`, 20 * time.Millisecond, http.StatusServiceUnavailable, `1`},
+		{`write retried later`, `POST`, 3 * time.Second, http.StatusServiceUnavailable, `3`},
+	}
+
+	for _, test := range tests {
+		var running int32
+		release := make(chan struct{})
+
+		s := NewServer(`/`, `:0`)
+		s.SetWriteConcurrencyLimit(1)
+		s.SetWriteConcurrencyMaxWait(test.maxWait)
+		s.SetRequestDeadline(100 * time.Millisecond)
+		implementation := ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			if context.Method == `POST` && atomic.AddInt32(&running, 1) == 1 {
+				<-release
+			}
+			return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(`done`)}
+		})
+		s.NewEndpoint(`/orders`,
+			ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/plain`}, Implementation: implementation},
+			ResourceHandler{Method: `POST`, ContentTypeOut: []string{`text/plain`}, Implementation: implementation})
+
+		// Saturates the write slot
+		first := make(chan *httptest.ResponseRecorder)
+		go func() {
+			first <- serveTestRequest(s, `POST`, `/orders`, nil, nil)
+		}()
+		for atomic.LoadInt32(&running) == 0 {
+			time.Sleep(time.Millisecond)
+		}
+
+		recorder := serveTestRequest(s, test.method, `/orders`, nil, nil)
+		close(release)
+		<-first
+
+		if recorder.Code != test.status {
+			t.Errorf(`%s : expected %d, got %d`, test.name, test.status, recorder.Code)
+		}
+		if recorder.Header().Get(`Retry-After`) != test.retryAfter {
+			t.Errorf(`%s : Retry-After %q, expected %q`, test.name, recorder.Header().Get(`Retry-After`), test.retryAfter)
+		}
+	}
+}
+
+func TestWriteConcurrencyMaxWaitGetsAFreedSlot(t *testing.T) {
+
+	var running int32
+	release := make(chan struct{})
+
+	s := NewServer(`/`, `:0`)
+	s.SetWriteConcurrencyLimit(1)
+	s.SetWriteConcurrencyMaxWait(time.Minute)
+	s.NewEndpoint(`/orders`, ResourceHandler{Method: `POST`, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+		if atomic.AddInt32(&running, 1) == 1 {
+			<-release
+		}
+		return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(`done`)}
+	})})
+
+	first := make(chan *httptest.ResponseRecorder)
+	go func() {
+		first <- serveTestRequest(s, `POST`, `/orders`, nil, nil)
+	}()
+	for atomic.LoadInt32(&running) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	time.AfterFunc(20*time.Millisecond, func() { close(release) })
+	if recorder := serveTestRequest(s, `POST`, `/orders`, nil, nil); recorder.Code != http.StatusOK {
+		t.Errorf(`waiting write : expected 200, got %d`, recorder.Code)
+	}
+	if recorder := <-first; recorder.Code != http.StatusOK {
+		t.Errorf(`first write : expected 200, got %d`, recorder.Code)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Request scoped values, set up by a context provider before the handler runs.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Registers a function run once the request is routed and validated, before the resource handler and its middlewares,
+// e.g. to open a transaction or load the user of a token and store them with ctx.Set
+// A returned *HTTPError is rendered with its status, e.g. 401, any other error as a 500, and the handler is not run
+func (s *Server) SetContextProvider(provider func(ctx *ResourceHandlerContext) error) {
+	s.contextProvider = provider
+}
+
+// Stores a request scoped value, e.g. from the context provider or a middleware
+func (c *ResourceHandlerContext) Set(key string, value interface{}) {
+	if c.values == nil {
+		c.values = make(map[string]interface{})
+	}
+	c.values[key] = value
+}
+
+// Returns a request scoped value stored with Set
+func (c *ResourceHandlerContext) Get(key string) (interface{}, bool) {
+	value, ok := c.values[key]
+	return value, ok
+}
+
+// Runs the context provider, renders its error if any, returns false if the request was aborted
+func (s *Server) provideContext(writer http.ResponseWriter, ctx *ResourceHandlerContext, requestId string) bool {
+
+	err := s.contextProvider(ctx)
+	if err == nil {
+		return true
+	}
+
+	Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Context provider returned an error : %s", requestId, err.Error()))
+
+	result := errorResult(err)
+	s.renderHandlerResult(writer, &result, `text/plain`, ctx, requestId)
+
+	return false
+}
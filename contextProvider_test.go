@@ -0,0 +1,114 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Context provider tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestContextProvider(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		authorization string
+		status        int
+		body          string
+		executed      bool
+	}{
+		{`value provided`, `Bearer bob`, http.StatusOK, `hello bob`, true},
+		{`http error`, ``, http.StatusUnauthorized, `Unauthorized`, false},
+		{`other error`, `Bearer broken`, http.StatusInternalServerError, `Internal Server Error`, false},
+	}
+
+	var middlewareSaw interface{}
+
+	s := NewServer(`/`, `:0`)
+	s.SetContextProvider(func(ctx *ResourceHandlerContext) error {
+		token := strings.TrimPrefix(ctx.Header.Get(`Authorization`), `Bearer `)
+		switch token {
+		case ``:
+			return NewHTTPError(http.StatusUnauthorized, `Unauthorized`)
+		case `broken`:
+			return errors.New(`token store unavailable`)
+		}
+		ctx.Set(`user`, token)
+		return nil
+	})
+
+	executed := false
+	s.NewEndpoint(`/greeting`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+		executed = true
+		user, _ := context.Get(`user`)
+		return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(`hello ` + user.(string))}
+	})})
+
+	// Provided values also reach the middlewares
+	s.UseOnEndpoint(`/greeting`, func(next ResourceHandlerImplementation) ResourceHandlerImplementation {
+		return ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			middlewareSaw, _ = context.Get(`user`)
+			return next.Execute(context)
+		})
+	})
+
+	for _, test := range tests {
+		executed = false
+		middlewareSaw = nil
+
+		recorder := serveTestRequest(s, `GET`, `/greeting`, nil, map[string]string{`Authorization`: test.authorization})
+		if recorder.Code != test.status || recorder.Body.String() != test.body || executed != test.executed {
+			t.Errorf(`%s : got %d %q executed %t, expected %d %q executed %t`, test.name, recorder.Code, recorder.Body.String(), executed, test.status, test.body, test.executed)
+		}
+		if test.executed && middlewareSaw != `bob` {
+			t.Errorf(`%s : the middleware got %v`, test.name, middlewareSaw)
+		}
+	}
+}
+
+func TestContextValues(t *testing.T) {
+
+	context := NewTestContext(WithValue(`tenant`, `acme`))
+	context.Set(`user`, 42)
+
+	tests := []struct {
+		key   string
+		value interface{}
+		ok    bool
+	}{
+		{`tenant`, `acme`, true},
+		{`user`, 42, true},
+		{`missing`, nil, false},
+	}
+
+	for _, test := range tests {
+		if value, ok := context.Get(test.key); value != test.value || ok != test.ok {
+			t.Errorf(`%s : got %v %t, expected %v %t`, test.key, value, ok, test.value, test.ok)
+		}
+	}
+}
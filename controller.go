@@ -0,0 +1,102 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Registration of an endpoint from the methods of a controller struct.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Controller method names and the HTTP method they are registered for
+var controllerMethods = []struct{ name, httpMethod string }{
+	{`Get`, `GET`},
+	{`Head`, `HEAD`},
+	{`Post`, `POST`},
+	{`Put`, `PUT`},
+	{`Patch`, `PATCH`},
+	{`Delete`, `DELETE`},
+	{`Options`, `OPTIONS`},
+}
+
+// Implemented by a controller to choose its content types, application/json otherwise
+type ControllerContentTypes interface {
+	ContentTypes() (contentTypeIn []string, contentTypeOut []string)
+}
+
+// Registers an endpoint on route whose resource handlers are the methods of controller named after HTTP methods :
+// Get, Head, Post, Put, Patch, Delete and Options.
+// Such a method takes a *ResourceHandlerContext and returns a ResourceHandlerResult, or a ResourceHandlerResult and an error like ErrorHandlerFunc.
+// Only Post, Put and Patch accept a request body. Other methods of the controller are ignored.
+func (s *Server) RegisterController(route string, controller interface{}) error {
+	return s.recordRegistrationError(s.registerController(route, controller))
+}
+
+func (s *Server) registerController(route string, controller interface{}) error {
+
+	if controller == nil {
+		return errors.New(fmt.Sprintf(`Controller for route %s is nil`, route))
+	}
+
+	contentTypeIn, contentTypeOut := []string{`application/json`}, []string{`application/json`}
+	if c, ok := controller.(ControllerContentTypes); ok {
+		contentTypeIn, contentTypeOut = c.ContentTypes()
+	}
+
+	value := reflect.ValueOf(controller)
+
+	resourceHandlers := []ResourceHandler{}
+	for _, m := range controllerMethods {
+
+		method := value.MethodByName(m.name)
+		if !method.IsValid() {
+			continue
+		}
+
+		var implementation ResourceHandlerImplementation
+		switch fn := method.Interface().(type) {
+		case func(*ResourceHandlerContext) ResourceHandlerResult:
+			implementation = ResourceHandlerFunc(fn)
+		case func(*ResourceHandlerContext) (ResourceHandlerResult, error):
+			implementation = ErrorHandlerFunc(fn)
+		default:
+			return errors.New(fmt.Sprintf(`Controller method %s for route %s has an invalid signature %s`, m.name, route, method.Type().String()))
+		}
+
+		resourceHandler := ResourceHandler{Method: m.httpMethod, ContentTypeIn: []string{}, ContentTypeOut: contentTypeOut, Implementation: implementation}
+		if m.httpMethod == `POST` || m.httpMethod == `PUT` || m.httpMethod == `PATCH` {
+			resourceHandler.ContentTypeIn = contentTypeIn
+		}
+
+		resourceHandlers = append(resourceHandlers, resourceHandler)
+	}
+
+	if len(resourceHandlers) == 0 {
+		return errors.New(fmt.Sprintf(`Controller %s for route %s has no HTTP method`, value.Type().String(), route))
+	}
+
+	return s.addEndpointToRouter(s.router, route, resourceHandlers...)
+}
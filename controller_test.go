@@ -0,0 +1,113 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Controller registration tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type usersTestController struct {
+	names []string
+}
+
+func (c *usersTestController) Get(context *ResourceHandlerContext) ResourceHandlerResult {
+	return NewResult(http.StatusOK, c.names)
+}
+
+func (c *usersTestController) Post(context *ResourceHandlerContext) (ResourceHandlerResult, error) {
+	var name string
+	if err := context.Decode(&name); err != nil {
+		return ResourceHandlerResult{}, err
+	}
+	c.names = append(c.names, name)
+	return NewResult(http.StatusCreated, name), nil
+}
+
+// Not named after an HTTP method, ignored
+func (c *usersTestController) Count() int {
+	return len(c.names)
+}
+
+type invalidTestController struct{}
+
+func (c invalidTestController) Get() string {
+	return `users`
+}
+
+func TestRegisterController(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		status int
+		result string
+	}{
+		{`get`, `GET`, ``, http.StatusOK, `["alice"]`},
+		{`post`, `POST`, `"bob"`, http.StatusCreated, `"bob"`},
+		{`get after post`, `GET`, ``, http.StatusOK, `["alice","bob"]`},
+		{`post error`, `POST`, `{bad`, http.StatusBadRequest, `Could not decode the body as application/json`},
+		{`method not declared`, `DELETE`, ``, http.StatusBadRequest, `No available resource`},
+	}
+
+	s := NewServer(`/`, `:0`)
+	if err := s.RegisterController(`/users`, &usersTestController{names: []string{`alice`}}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range tests {
+		header := map[string]string{}
+		if test.body != `` {
+			header[`Content-Type`] = `application/json`
+		}
+		recorder := serveTestRequest(s, test.method, `/users`, strings.NewReader(test.body), header)
+		if recorder.Code != test.status || !strings.Contains(recorder.Body.String(), test.result) {
+			t.Errorf(`%s : got %d %q, expected %d containing %q`, test.name, recorder.Code, recorder.Body.String(), test.status, test.result)
+		}
+	}
+}
+
+func TestRegisterControllerErrors(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		controller interface{}
+		err        string
+	}{
+		{`nil`, nil, `Controller for route /items is nil`},
+		{`invalid signature`, invalidTestController{}, `Controller method Get for route /items has an invalid signature func() string`},
+		{`no http method`, struct{}{}, `Controller struct {} for route /items has no HTTP method`},
+	}
+
+	for _, test := range tests {
+		s := NewServer(`/`, `:0`)
+		if err := s.RegisterController(`/items`, test.controller); err == nil || err.Error() != test.err {
+			t.Errorf(`%s : expected %q, got %v`, test.name, test.err, err)
+		}
+	}
+}
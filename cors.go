@@ -0,0 +1,138 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Cross-origin resource sharing : preflight answers and Access-Control headers.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var defaultCORSMethods = []string{`GET`, `HEAD`, `POST`, `PUT`, `PATCH`, `DELETE`}
+
+type CORSOptions struct {
+	AllowedOrigins   []string      // * allows any origin, answered with a literal *
+	AllowedMethods   []string      // answered to preflights, defaults to the methods of the endpoint
+	AllowedHeaders   []string      // answered to preflights, defaults to the requested headers
+	AllowCredentials bool          // only for the origins listed explicitly, browsers reject credentials with *
+	MaxAge           time.Duration // how long browsers may cache a preflight answer, omitted when 0
+
+	// Preflights to routes that are not registered get a 204 too, instead of a 404, e.g. when browsers
+	// probe paths served elsewhere ; off by default as it masks real 404s to preflights
+	PreflightUnknownRoutes bool
+}
+
+// Answers CORS preflights and adds Access-Control headers to the responses to allowed origins
+func (s *Server) EnableCORS(options CORSOptions) {
+
+	Flog(FLOG_TYPE_ACTION, fmt.Sprintf("Enabling CORS for origins %s\n", strings.Join(options.AllowedOrigins, `, `)))
+
+	if options.AllowCredentials && isCORSWildcard(options.AllowedOrigins) {
+		Flog(FLOG_TYPE_WARNING, "CORS credentials are not allowed for origins only matched by *\n")
+	}
+
+	s.cors = &options
+}
+
+// True for an OPTIONS request a browser sends before a cross-origin one
+func isCORSPreflight(request *http.Request) bool {
+	return request.Method == `OPTIONS` && request.Header.Get(`Origin`) != `` && request.Header.Get(`Access-Control-Request-Method`) != ``
+}
+
+func isCORSWildcard(allowedOrigins []string) bool {
+
+	for _, allowedOrigin := range allowedOrigins {
+		if allowedOrigin == `*` {
+			return true
+		}
+	}
+
+	return false
+}
+
+// True if the origin is listed explicitly, not only matched by *
+func (s *Server) isCORSOriginListed(origin string) bool {
+
+	for _, allowedOrigin := range s.cors.AllowedOrigins {
+		if strings.EqualFold(allowedOrigin, origin) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Adds the Access-Control headers of an allowed origin, returns false if the origin is not allowed
+// An origin listed explicitly is echoed, with credentials if allowed, any other one matched by * gets a literal *
+func (s *Server) setCORSHeaders(writer http.ResponseWriter, request *http.Request) bool {
+
+	origin := request.Header.Get(`Origin`)
+	if origin == `` {
+		return false
+	}
+
+	if s.isCORSOriginListed(origin) {
+		writer.Header().Set(`Access-Control-Allow-Origin`, origin)
+		writer.Header().Add(`Vary`, `Origin`)
+		if s.cors.AllowCredentials {
+			writer.Header().Set(`Access-Control-Allow-Credentials`, `true`)
+		}
+		return true
+	}
+
+	if isCORSWildcard(s.cors.AllowedOrigins) {
+		writer.Header().Set(`Access-Control-Allow-Origin`, `*`)
+		return true
+	}
+
+	return false
+}
+
+// Answers a preflight with a 204, methods being those of the endpoint, nil if the route is not registered
+func (s *Server) serveCORSPreflight(writer http.ResponseWriter, request *http.Request, methods []string, requestId string) {
+
+	if len(s.cors.AllowedMethods) > 0 {
+		methods = s.cors.AllowedMethods
+	}
+	if len(methods) == 0 {
+		methods = defaultCORSMethods
+	}
+	writer.Header().Set(`Access-Control-Allow-Methods`, strings.Join(methods, `, `))
+
+	if len(s.cors.AllowedHeaders) > 0 {
+		writer.Header().Set(`Access-Control-Allow-Headers`, strings.Join(s.cors.AllowedHeaders, `, `))
+	} else if request.Header.Get(`Access-Control-Request-Headers`) != `` {
+		writer.Header().Set(`Access-Control-Allow-Headers`, request.Header.Get(`Access-Control-Request-Headers`))
+	}
+
+	if s.cors.MaxAge > 0 {
+		writer.Header().Set(`Access-Control-Max-Age`, strconv.FormatInt(int64(s.cors.MaxAge/time.Second), 10))
+	}
+
+	s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusNoContent}, `text/plain`, requestId)
+}
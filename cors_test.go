@@ -0,0 +1,108 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Cross-origin resource sharing tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCORSPreflight(t *testing.T) {
+
+	preflight := map[string]string{`Origin`: `https://app.example`, `Access-Control-Request-Method`: `PUT`, `Access-Control-Request-Headers`: `X-Token`}
+
+	s := NewServer(`/`, `:0`)
+	s.EnableCORS(CORSOptions{AllowedOrigins: []string{`https://app.example`}, MaxAge: time.Hour})
+	s.NewEndpoint(`/known`, textResourceHandler(`GET`, `ok`))
+
+	wildcard := NewServer(`/`, `:0`)
+	wildcard.EnableCORS(CORSOptions{AllowedOrigins: []string{`*`}, PreflightUnknownRoutes: true})
+
+	tests := []struct {
+		name    string
+		server  *Server
+		method  string
+		target  string
+		header  map[string]string
+		status  int
+		headers map[string]string
+	}{
+		{`preflight to a known route`, s, `OPTIONS`, `/known`, preflight, http.StatusNoContent, map[string]string{`Access-Control-Allow-Origin`: `https://app.example`, `Access-Control-Allow-Methods`: `GET, OPTIONS`, `Access-Control-Allow-Headers`: `X-Token`, `Access-Control-Max-Age`: `3600`}},
+		{`preflight to an unknown route`, s, `OPTIONS`, `/unknown`, preflight, http.StatusNotFound, nil},
+		{`preflight to an unknown route, answered`, wildcard, `OPTIONS`, `/not/registered`, preflight, http.StatusNoContent, map[string]string{`Access-Control-Allow-Origin`: `*`, `Access-Control-Allow-Methods`: `GET, HEAD, POST, PUT, PATCH, DELETE`}},
+		{`allowed origin`, s, `GET`, `/known`, map[string]string{`Origin`: `https://app.example`}, http.StatusOK, map[string]string{`Access-Control-Allow-Origin`: `https://app.example`}},
+		{`other origin`, s, `GET`, `/known`, map[string]string{`Origin`: `https://evil.example`}, http.StatusOK, map[string]string{`Access-Control-Allow-Origin`: ``}},
+		{`unknown route, not a preflight`, wildcard, `GET`, `/not/registered`, map[string]string{`Origin`: `https://app.example`}, http.StatusNotFound, nil},
+	}
+
+	for _, test := range tests {
+		recorder := serveTestRequest(test.server, test.method, test.target, nil, test.header)
+		if recorder.Code != test.status {
+			t.Errorf(`%s : expected %d, got %d`, test.name, test.status, recorder.Code)
+		}
+		for name, value := range test.headers {
+			if got := recorder.Header().Get(name); got != value {
+				t.Errorf(`%s : %s is %q, expected %q`, test.name, name, got, value)
+			}
+		}
+	}
+}
+
+func TestCORSWildcardNeverAllowsCredentials(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		origins     []string
+		origin      string
+		allowOrigin string
+		credentials string
+		vary        string
+	}{
+		{`wildcard`, []string{`*`}, `https://app.example`, `*`, ``, ``},
+		{`listed`, []string{`https://app.example`}, `https://app.example`, `https://app.example`, `true`, `Origin`},
+		{`listed with wildcard`, []string{`https://app.example`, `*`}, `https://app.example`, `https://app.example`, `true`, `Origin`},
+		{`unlisted with wildcard`, []string{`https://app.example`, `*`}, `https://other.example`, `*`, ``, ``},
+		{`unlisted`, []string{`https://app.example`}, `https://other.example`, ``, ``, ``},
+	}
+
+	for _, test := range tests {
+		s := NewServer(`/`, `:0`)
+		s.EnableCORS(CORSOptions{AllowedOrigins: test.origins, AllowCredentials: true})
+		s.NewEndpoint(`/known`, textResourceHandler(`GET`, `ok`))
+
+		recorder := serveTestRequest(s, `GET`, `/known`, nil, map[string]string{`Origin`: test.origin})
+		if got := recorder.Header().Get(`Access-Control-Allow-Origin`); got != test.allowOrigin {
+			t.Errorf(`%s : Access-Control-Allow-Origin is %q, expected %q`, test.name, got, test.allowOrigin)
+		}
+		if got := recorder.Header().Get(`Access-Control-Allow-Credentials`); got != test.credentials {
+			t.Errorf(`%s : Access-Control-Allow-Credentials is %q, expected %q`, test.name, got, test.credentials)
+		}
+		if got := recorder.Header().Get(`Vary`); got != test.vary {
+			t.Errorf(`%s : Vary is %q, expected %q`, test.name, got, test.vary)
+		}
+	}
+}
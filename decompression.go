@@ -0,0 +1,101 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Automatic decompression of gzip and deflate encoded request bodies.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"strings"
+)
+
+var errDecompressedBodyTooLarge = errors.New(`Decompressed request body is too large`)
+var errUnsupportedContentEncoding = errors.New(`Unsupported Content-Encoding`)
+
+// Decompresses gzip and deflate request bodies before they reach resource handlers,
+// maxSize bounds the decompressed size to guard against decompression bombs, zero for no limit
+func (s *Server) EnableRequestDecompression(maxSize int64) {
+	s.requestDecompressionEnabled = true
+	s.requestDecompressionMaxSize = maxSize
+}
+
+// Wraps a body according to its Content-Encoding
+func newDecompressingReader(contentEncoding string, body io.Reader, maxSize int64) (io.Reader, error) {
+
+	var reader io.Reader
+	var err error
+
+	switch strings.ToLower(strings.TrimSpace(contentEncoding)) {
+
+	case `gzip`, `x-gzip`:
+		reader, err = gzip.NewReader(body)
+
+	case `deflate`:
+		reader, err = zlib.NewReader(body)
+
+	default:
+		return nil, errUnsupportedContentEncoding
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if maxSize <= 0 {
+		return reader, nil
+	}
+
+	return &decompressionLimitReader{reader: reader, remaining: maxSize}, nil
+}
+
+// Fails with errDecompressedBodyTooLarge instead of silently truncating
+type decompressionLimitReader struct {
+	reader    io.Reader
+	remaining int64
+}
+
+func (l *decompressionLimitReader) Read(p []byte) (int, error) {
+
+	if l.remaining <= 0 {
+		// Anything left means the limit is exceeded
+		var probe [1]byte
+		n, _ := io.ReadFull(l.reader, probe[:])
+		if n > 0 {
+			return 0, errDecompressedBodyTooLarge
+		}
+		return 0, io.EOF
+	}
+
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+
+	n, err := l.reader.Read(p)
+	l.remaining -= int64(n)
+
+	return n, err
+}
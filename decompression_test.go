@@ -0,0 +1,79 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Request decompression tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRequestDecompression(t *testing.T) {
+
+	tests := []struct {
+		name            string
+		maxSize         int64
+		contentEncoding string
+		body            string
+		status          int
+	}{
+		{`gzip`, 10, `gzip`, `hello`, http.StatusOK},
+		{`deflate`, 10, `deflate`, `hello`, http.StatusOK},
+		{`exactly the max size`, 5, `gzip`, `hello`, http.StatusOK},
+		{`over the max size`, 10, `gzip`, `hello world!!`, http.StatusRequestEntityTooLarge},
+		{`no limit`, 0, `gzip`, strings.Repeat(`hello`, 100000), http.StatusOK},
+		{`unsupported encoding`, 0, `br`, `hello`, http.StatusUnsupportedMediaType},
+	}
+
+	for _, test := range tests {
+		s := NewServer(`/`, `:0`)
+		s.EnableRequestDecompression(test.maxSize)
+		s.NewEndpoint(`/echo`, ResourceHandler{Method: `POST`, ContentTypeIn: []string{`text/plain`}, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBuffer(context.Body.Bytes())}
+		})})
+
+		body := new(bytes.Buffer)
+		var compressor io.WriteCloser = gzip.NewWriter(body)
+		if test.contentEncoding == `deflate` {
+			compressor = zlib.NewWriter(body)
+		}
+		io.WriteString(compressor, test.body)
+		compressor.Close()
+
+		recorder := serveTestRequest(s, `POST`, `/echo`, body, map[string]string{`Content-Type`: `text/plain`, `Content-Encoding`: test.contentEncoding})
+
+		if recorder.Code != test.status {
+			t.Errorf(`%s : expected %d, got %d %q`, test.name, test.status, recorder.Code, recorder.Body.String())
+		}
+		if test.status == http.StatusOK && recorder.Body.String() != test.body {
+			t.Errorf(`%s : handler got %d bytes, expected %d`, test.name, recorder.Body.Len(), len(test.body))
+		}
+	}
+}
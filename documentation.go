@@ -27,14 +27,16 @@ package gorip
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"html"
 	"io/ioutil"
 	"net/http"
 	"strconv"
 	"strings"
 )
 
-func (s *Server) serveDocumentation(writer http.ResponseWriter) {
+func (s *Server) serveDocumentation(writer http.ResponseWriter, request *http.Request) {
 
 	documentation := new(bytes.Buffer)
 
@@ -83,12 +85,34 @@ func (s *Server) serveDocumentation(writer http.ResponseWriter) {
 
 	writer.WriteHeader(http.StatusOK)
 
-	if bodyOutLen > 0 {
+	// HEAD : same headers, Content-Length included, but no body
+	if bodyOutLen > 0 && request.Method != `HEAD` {
 		documentation.WriteTo(writer)
 	}
 
 }
 
+// Renders the denial of the documentation auth, with the status of an *HTTPError, or else a 403
+func (s *Server) denyDocumentation(writer http.ResponseWriter, err error, requestId string) {
+
+	result := ResourceHandlerResult{HttpStatus: http.StatusForbidden}
+
+	var httpError *HTTPError
+	if errors.As(err, &httpError) {
+		result.HttpStatus = httpError.Status
+	}
+
+	if result.HttpStatus == http.StatusUnauthorized && s.documentationChallenge != `` {
+		result = result.withHeader(`WWW-Authenticate`, s.documentationChallenge)
+	}
+
+	message := fmt.Sprintf("Access to the documentation is denied")
+	Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Access to the documentation is denied : %s", requestId, err.Error()))
+	result.Body = bytes.NewBufferString(message)
+
+	s.renderResourceResult(writer, &result, `text/plain`, requestId)
+}
+
 func (s *Server) serveDocumentationRecursive(currentPath string, currentNode routerNode, buffer *bytes.Buffer) {
 
 	path := currentPath + currentNode.GetPart()
@@ -102,13 +126,24 @@ func (s *Server) serveDocumentationRecursive(currentPath string, currentNode rou
 
 		for _, r := range resourceHandlers {
 
-			buffer.WriteString(`<h3>` + r.Method + `</h3>` + "\n")
+			if r.Documentation != nil && len(r.Documentation.Summary) > 0 {
+				buffer.WriteString(`<h3>` + r.Method + ` - ` + html.EscapeString(r.Documentation.Summary) + `</h3>` + "\n")
+			} else {
+				buffer.WriteString(`<h3>` + r.Method + `</h3>` + "\n")
+			}
+
+			if r.Documentation != nil && len(r.Documentation.Description) > 0 {
+				buffer.WriteString(`<p>` + html.EscapeString(r.Documentation.Description) + `</p>` + "\n")
+			}
 
 			buffer.WriteString(`<div class="left">` + "\n")
 			buffer.WriteString(`<h4>Content Type</h4>` + "\n")
 			buffer.WriteString(`<table>` + "\n")
 			buffer.WriteString(`<tr><td>In</td><td>` + strings.Join(r.ContentTypeIn, `,`) + `</td>` + "\n")
 			buffer.WriteString(`<tr><td>Out</td><td>` + strings.Join(r.ContentTypeOut, `,`) + `</td>` + "\n")
+			for _, statusContentTypes := range r.ResponseContentTypes {
+				buffer.WriteString(`<tr><td>Out ` + statusContentTypes.String() + `</td><td>` + strings.Join(statusContentTypes.ContentTypes, `,`) + `</td>` + "\n")
+			}
 			buffer.WriteString(`</table>` + "\n")
 
 			buffer.WriteString(`<h4>Query Parameters</h4>` + "\n")
@@ -120,6 +155,9 @@ func (s *Server) serveDocumentationRecursive(currentPath string, currentNode rou
 				if q.FormatValidator != nil {
 					buffer.WriteString(` Validator : ` + q.FormatValidator.GetErrorMessage())
 				}
+				for _, validator := range q.FormatValidators {
+					buffer.WriteString(` Validator : ` + validator.GetErrorMessage())
+				}
 			}
 			buffer.WriteString(`</table>` + "\n")
 			buffer.WriteString(`</div>` + "\n")
@@ -155,6 +193,16 @@ func (s *Server) serveDocumentationRecursive(currentPath string, currentNode rou
 					buffer.WriteString(`<pre>` + testResultString + `</pre>` + "\n")
 				}
 
+				if len(rhd.ExampleRequest) > 0 {
+					buffer.WriteString(`<h4>Example Request</h4>` + "\n")
+					buffer.WriteString(`<pre>` + html.EscapeString(rhd.ExampleRequest) + `</pre>` + "\n")
+				}
+
+				if len(rhd.ExampleResponse) > 0 {
+					buffer.WriteString(`<h4>Example Response</h4>` + "\n")
+					buffer.WriteString(`<pre>` + html.EscapeString(rhd.ExampleResponse) + `</pre>` + "\n")
+				}
+
 				if len(rhd.AdditionalNotes) > 0 {
 					buffer.WriteString(`<h4>Additional Notes</h4>` + "\n")
 					buffer.WriteString(`<p>` + rhd.AdditionalNotes + `</p>` + "\n")
@@ -0,0 +1,249 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Documentation endpoint tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDocumentationMetadata(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		documentation *ResourceHandlerDocumentation
+		contains      []string
+		excludes      []string
+	}{
+		{`no metadata`, nil, []string{`<h2>/users</h2>`, `<h3>GET</h3>`}, nil},
+		{
+			`metadata`,
+			&ResourceHandlerDocumentation{Summary: `List users`, Description: `Users of the account`, ExampleRequest: `GET /users`, ExampleResponse: `[{"name":"bob"}]`},
+			[]string{`<h3>GET - List users</h3>`, `<p>Users of the account</p>`, `<pre>GET /users</pre>`, `<pre>[{&#34;name&#34;:&#34;bob&#34;}]</pre>`},
+			nil,
+		},
+		{
+			`escaped metadata`,
+			&ResourceHandlerDocumentation{Summary: `<script>alert(1)</script>`, Description: `Users <b>& groups</b>`},
+			[]string{`<h3>GET - &lt;script&gt;alert(1)&lt;/script&gt;</h3>`, `<p>Users &lt;b&gt;&amp; groups&lt;/b&gt;</p>`},
+			[]string{`<script>`, `<b>`},
+		},
+	}
+
+	for _, test := range tests {
+		handler := textResourceHandler(`GET`, `users`)
+		handler.Documentation = test.documentation
+
+		s := NewServer(`/`, `:0`)
+		s.EnableDocumentationEndpoint(`/doc`)
+		s.NewEndpoint(`/users`, handler)
+
+		recorder := serveTestRequest(s, `GET`, `/doc`, nil, nil)
+		if recorder.Code != http.StatusOK {
+			t.Errorf(`%s : expected 200, got %d`, test.name, recorder.Code)
+		}
+		for _, expected := range test.contains {
+			if !strings.Contains(recorder.Body.String(), expected) {
+				t.Errorf(`%s : %q is not documented`, test.name, expected)
+			}
+		}
+		for _, unexpected := range test.excludes {
+			if strings.Contains(recorder.Body.String(), unexpected) {
+				t.Errorf(`%s : %q is documented unescaped`, test.name, unexpected)
+			}
+		}
+	}
+}
+
+func TestDocumentationHead(t *testing.T) {
+
+	s := NewServer(`/`, `:0`)
+	s.EnableDocumentationEndpoint(`/doc`)
+	s.NewEndpoint(`/users`, textResourceHandler(`GET`, `users`))
+
+	get := serveTestRequest(s, `GET`, `/doc`, nil, nil)
+	head := serveTestRequest(s, `HEAD`, `/doc`, nil, nil)
+
+	tests := []struct {
+		name     string
+		recorder *httptest.ResponseRecorder
+		body     int
+	}{
+		{`GET`, get, get.Body.Len()},
+		{`HEAD`, head, 0},
+	}
+
+	for _, test := range tests {
+		if test.recorder.Code != http.StatusOK {
+			t.Errorf(`%s : expected 200, got %d`, test.name, test.recorder.Code)
+		}
+		if test.recorder.Header().Get(`Content-Length`) != strconv.Itoa(get.Body.Len()) || test.recorder.Header().Get(`Content-Type`) != get.Header().Get(`Content-Type`) {
+			t.Errorf(`%s : headers %v`, test.name, test.recorder.Header())
+		}
+		if test.recorder.Body.Len() != test.body {
+			t.Errorf(`%s : body of %d bytes, expected %d`, test.name, test.recorder.Body.Len(), test.body)
+		}
+	}
+}
+
+func TestDocumentationAuth(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		authorization string
+		status        int
+		challenge     string
+	}{
+		{`allowed`, `Bearer admin`, http.StatusOK, ``},
+		{`no credentials`, ``, http.StatusUnauthorized, `Bearer realm="documentation"`},
+		{`wrong credentials`, `Bearer guest`, http.StatusForbidden, ``},
+	}
+
+	s := NewServer(`/`, `:0`)
+	s.EnableDocumentationEndpoint(`/doc`)
+	s.SetDocumentationAuth(func(r *http.Request) error {
+		switch r.Header.Get(`Authorization`) {
+		case `Bearer admin`:
+			return nil
+		case ``:
+			return NewHTTPError(http.StatusUnauthorized, `credentials are required`)
+		}
+		return NewHTTPError(http.StatusForbidden, `not an administrator`)
+	}, `Bearer realm="documentation"`)
+	s.NewEndpoint(`/users`, textResourceHandler(`GET`, `users`))
+
+	for _, test := range tests {
+		recorder := serveTestRequest(s, `GET`, `/doc`, nil, map[string]string{`Authorization`: test.authorization})
+		if recorder.Code != test.status {
+			t.Errorf(`%s : expected %d, got %d`, test.name, test.status, recorder.Code)
+		}
+		if challenge := recorder.Header().Get(`WWW-Authenticate`); challenge != test.challenge {
+			t.Errorf(`%s : challenge %q, expected %q`, test.name, challenge, test.challenge)
+		}
+		if documented := strings.Contains(recorder.Body.String(), `<h2>/users</h2>`); documented != (test.status == http.StatusOK) {
+			t.Errorf(`%s : documentation served %t`, test.name, documented)
+		}
+	}
+
+	// Other endpoints are not guarded
+	if recorder := serveTestRequest(s, `GET`, `/users`, nil, nil); recorder.Code != http.StatusOK {
+		t.Errorf(`/users : expected 200, got %d`, recorder.Code)
+	}
+}
+
+func TestDocumentationAuthDenial(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		auth   func(r *http.Request) error
+		status int
+		body   string
+	}{
+		{`no guard`, nil, http.StatusOK, `<h2>/users</h2>`},
+		{`guard denies without status`, func(r *http.Request) error { return errors.New(`session expired`) }, http.StatusForbidden, `Access to the documentation is denied`},
+		{`guard denies with a 401`, func(r *http.Request) error { return NewHTTPError(http.StatusUnauthorized, `no session`) }, http.StatusUnauthorized, `Access to the documentation is denied`},
+		{`guard allows`, func(r *http.Request) error { return nil }, http.StatusOK, `<h2>/users</h2>`},
+	}
+
+	for _, test := range tests {
+		s := NewServer(`/`, `:0`)
+		s.EnableDocumentationEndpoint(`/doc`)
+		s.SetDocumentationAuth(test.auth, `Cookie realm="documentation"`)
+		s.NewEndpoint(`/users`, textResourceHandler(`GET`, `users`))
+
+		// Cookie authentication : a denial is not told from the presence of an Authorization header
+		recorder := serveTestRequest(s, `GET`, `/doc`, nil, map[string]string{`Cookie`: `session=1`})
+		if recorder.Code != test.status || !strings.Contains(recorder.Body.String(), test.body) {
+			t.Errorf(`%s : got %d %q, expected %d containing %q`, test.name, recorder.Code, recorder.Body.String(), test.status, test.body)
+		}
+		if challenged := recorder.Header().Get(`WWW-Authenticate`) != ``; challenged != (test.status == http.StatusUnauthorized) {
+			t.Errorf(`%s : challenged %t`, test.name, challenged)
+		}
+	}
+}
+
+func TestDocumentationResponseContentTypes(t *testing.T) {
+
+	s := NewServer(`/`, `:0`)
+	s.EnableDocumentationEndpoint(`/doc`)
+	handler := textResourceHandler(`GET`, `users`)
+	handler.ResponseContentTypes = []StatusContentTypes{
+		{MinStatus: 200, MaxStatus: 200, ContentTypes: []string{`application/json`}},
+		{MinStatus: 400, MaxStatus: 499, ContentTypes: []string{`application/problem+json`}},
+	}
+	s.NewEndpoint(`/users`, handler)
+
+	body := serveTestRequest(s, `GET`, `/doc`, nil, nil).Body.String()
+
+	tests := []string{
+		`<tr><td>Out 200</td><td>application/json</td>`,
+		`<tr><td>Out 400-499</td><td>application/problem+json</td>`,
+	}
+
+	for _, test := range tests {
+		if !strings.Contains(body, test) {
+			t.Errorf(`%s : not documented`, test)
+		}
+	}
+}
+
+func TestDebugCheckResponseContentTypes(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		enabled bool
+		status  int
+		warned  bool
+	}{
+		{`disabled`, false, http.StatusNotFound, false},
+		{`declared`, true, http.StatusOK, false},
+		{`undeclared`, true, http.StatusNotFound, true},
+		{`status without declaration`, true, http.StatusInternalServerError, false},
+	}
+
+	for _, test := range tests {
+		s := NewServer(`/`, `:0`)
+		s.DebugEnableCheckResponseContentTypes(test.enabled)
+		s.NewEndpoint(`/users`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/plain`}, ResponseContentTypes: []StatusContentTypes{
+			{MinStatus: 200, MaxStatus: 299, ContentTypes: []string{`text/plain`}},
+			{MinStatus: 400, MaxStatus: 499, ContentTypes: []string{`application/problem+json`}},
+		}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			return ResourceHandlerResult{HttpStatus: test.status, Body: bytes.NewBufferString(`users`)}
+		})})
+
+		logged := captureLog(func() {
+			serveTestRequest(s, `GET`, `/users`, nil, nil)
+		})
+		if warned := strings.Contains(logged, `is not declared for status`); warned != test.warned {
+			t.Errorf(`%s : warned %t, expected %t`, test.name, warned, test.warned)
+		}
+	}
+}
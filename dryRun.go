@@ -0,0 +1,73 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Dry-run requests : validated like any other, without the side effects of the handler.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	const_dry_run_query_parameter = `dryRun`
+	const_prefer_header           = `Prefer`
+	const_prefer_handling         = `handling=validate`
+)
+
+// Implemented by resource handlers that validate what the server can not, e.g. the decoded body, on dry-run requests
+// Handlers not implementing it get a 204 once the server validations passed
+type DryRunner interface {
+	DryRun(context *ResourceHandlerContext) ResourceHandlerResult
+}
+
+// True if the request asks for validation only, with ?dryRun=true or a Prefer: handling=validate header
+func isDryRunRequest(urlValues url.Values, header http.Header) bool {
+
+	if strings.EqualFold(urlValues.Get(const_dry_run_query_parameter), `true`) {
+		return true
+	}
+
+	for _, prefer := range header.Values(const_prefer_header) {
+		for _, preference := range strings.Split(prefer, `,`) {
+			if strings.EqualFold(strings.TrimSpace(preference), const_prefer_handling) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Result of a dry-run request, never calling Execute
+func dryRunResult(resource *ResourceHandler, context *ResourceHandlerContext) ResourceHandlerResult {
+
+	dryRunner, ok := resource.Implementation.(DryRunner)
+	if !ok {
+		return ResourceHandlerResult{HttpStatus: http.StatusNoContent}
+	}
+
+	return dryRunner.DryRun(context)
+}
@@ -0,0 +1,99 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Dry-run request tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// Implementation creating users, counting the ones created
+type createUserTestImplementation struct {
+	created int
+}
+
+func (i *createUserTestImplementation) Execute(context *ResourceHandlerContext) ResourceHandlerResult {
+	i.created++
+	return ResourceHandlerResult{HttpStatus: http.StatusCreated}
+}
+
+// Same, also validating the decoded body on dry-run requests
+type validatingCreateUserTestImplementation struct {
+	createUserTestImplementation
+}
+
+func (i *validatingCreateUserTestImplementation) DryRun(context *ResourceHandlerContext) ResourceHandlerResult {
+	var user map[string]string
+	if err := context.Decode(&user); err != nil {
+		return errorResult(err)
+	}
+	if user[`name`] == `` {
+		return errorResult(NewHTTPError(http.StatusUnprocessableEntity, `name is required`))
+	}
+	return ResourceHandlerResult{HttpStatus: http.StatusOK}
+}
+
+func TestDryRun(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		validating  bool
+		allowDryRun bool
+		query       string
+		prefer      string
+		body        string
+		status      int
+		created     int
+	}{
+		{`regular request`, false, true, ``, ``, `{"name":"bob"}`, http.StatusCreated, 1},
+		{`dry-run query parameter`, false, true, `?dryRun=true`, ``, `{"name":"bob"}`, http.StatusNoContent, 0},
+		{`prefer header`, false, true, ``, `respond-async, handling=validate`, `{"name":"bob"}`, http.StatusNoContent, 0},
+		{`dry-run not allowed`, false, false, `?dryRun=true`, ``, `{"name":"bob"}`, http.StatusCreated, 1},
+		{`server validation failure`, false, true, `?dryRun=true&limit=abc`, ``, `{"name":"bob"}`, http.StatusBadRequest, 0},
+		{`handler validation`, true, true, `?dryRun=true`, ``, `{"name":"bob"}`, http.StatusOK, 0},
+		{`handler validation failure`, true, true, `?dryRun=true`, ``, `{"name":""}`, http.StatusUnprocessableEntity, 0},
+		{`handler decode failure`, true, true, `?dryRun=true`, ``, `{bad`, http.StatusBadRequest, 0},
+	}
+
+	for _, test := range tests {
+		creator := &createUserTestImplementation{}
+		var implementation ResourceHandlerImplementation = creator
+		if test.validating {
+			validating := &validatingCreateUserTestImplementation{}
+			implementation = validating
+			creator = &validating.createUserTestImplementation
+		}
+
+		s := NewServer(`/`, `:0`)
+		s.NewEndpoint(`/users`, ResourceHandler{Method: `POST`, ContentTypeIn: []string{`application/json`}, ContentTypeOut: []string{`text/plain`}, AllowDryRun: test.allowDryRun, QueryParameters: map[string]QueryParameter{`limit`: {Kind: QueryParameterInt, DefaultValue: `10`}}, Implementation: implementation})
+
+		recorder := serveTestRequest(s, `POST`, `/users`+test.query, strings.NewReader(test.body), map[string]string{`Content-Type`: `application/json`, `Prefer`: test.prefer})
+		if recorder.Code != test.status || creator.created != test.created {
+			t.Errorf(`%s : got %d creating %d users, expected %d creating %d`, test.name, recorder.Code, creator.created, test.status, test.created)
+		}
+	}
+}
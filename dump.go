@@ -0,0 +1,207 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Readable request dump for debugging, with redacted sensitive headers and a bounded body snapshot.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const (
+	const_redacted_header_value           = `[REDACTED]`
+	const_request_dump_default_body_limit = 1024
+)
+
+// Redacted in request dumps unless replaced with SetRedactedHeaders
+var defaultRedactedHeaders = []string{`Authorization`, `Cookie`}
+
+// Headers whose values are replaced in request dumps, Authorization and Cookie by default
+func (s *Server) SetRedactedHeaders(headers []string) {
+	s.redactedHeaders = headers
+}
+
+// Includes up to limit bytes of the body in request dumps, 1024 by default, zero leaves the body out
+func (s *Server) DebugSetRequestDumpBodyLimit(limit int64) {
+	s.debugRequestDumpBodyLimit = limit
+}
+
+// Keeps the original body closable once part of it was read ahead
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// Builds the dump of a request, close to its wire format : request line, remote address, sorted headers and body snapshot
+// What is read of the body is put back for the handlers
+func (s *Server) formatRequestDump(request *http.Request) []byte {
+
+	dump := new(bytes.Buffer)
+
+	target := request.URL.RequestURI()
+	if s.logMatchedRoute {
+		target = const_redacted_header_value
+	}
+
+	dump.WriteString(fmt.Sprintf("%s %s %s\n", request.Method, target, request.Proto))
+	dump.WriteString(fmt.Sprintf("Host: %s\n", request.Host))
+	dump.WriteString(fmt.Sprintf("Remote-Address: %s\n", request.RemoteAddr))
+
+	redacted := make(map[string]bool)
+	redactedHeaders := s.redactedHeaders
+	if redactedHeaders == nil {
+		redactedHeaders = defaultRedactedHeaders
+	}
+	for _, name := range redactedHeaders {
+		redacted[http.CanonicalHeaderKey(name)] = true
+	}
+
+	names := []string{}
+	for name := range request.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := strings.Join(request.Header[name], `, `)
+		if redacted[name] {
+			value = const_redacted_header_value
+		}
+		dump.WriteString(fmt.Sprintf("%s: %s\n", name, value))
+	}
+
+	if s.debugRequestDumpBodyLimit > 0 && request.Body != nil && request.Body != http.NoBody {
+
+		// One more byte tells whether the snapshot is the whole body
+		head, _ := ioutil.ReadAll(io.LimitReader(request.Body, s.debugRequestDumpBodyLimit+1))
+		request.Body = &readCloser{Reader: io.MultiReader(bytes.NewReader(head), request.Body), Closer: request.Body}
+
+		if len(head) > 0 {
+			dump.WriteString("\n")
+			dump.WriteString(formatBodySnapshot(head, s.debugRequestDumpBodyLimit))
+			dump.WriteString("\n")
+		}
+	}
+
+	return dump.Bytes()
+}
+
+// Body cut to limit bytes, with a marker when it was truncated
+func formatBodySnapshot(body []byte, limit int64) string {
+
+	if int64(len(body)) <= limit {
+		return string(body)
+	}
+
+	return fmt.Sprintf("%s\n[body truncated to %d bytes]", body[:limit], limit)
+}
+
+// Logs up to limit bytes of the body of every response, zero, the default, logs none
+// Bodies may hold personal data : meant for debugging only, see DebugSetResponseBodyRedactor
+func (s *Server) DebugEnableLogResponseBody(limit int64) {
+	s.debugResponseBodyLogLimit = limit
+}
+
+// Registers a function rewriting the logged part of response bodies, e.g. to mask the value of a password field
+// It gets the response Content-Type and at most the DebugEnableLogResponseBody limit of bytes
+func (s *Server) DebugSetResponseBodyRedactor(redactor func(contentType string, body []byte) []byte) {
+	s.responseBodyRedactor = redactor
+}
+
+// Keeps the first bytes of the response body written through it, before any compression
+type responseBodyLogger struct {
+	http.ResponseWriter
+	limit    int64
+	snapshot []byte
+	size     int64
+	redacted []string // replaced in the snapshot, see SetLogMatchedRoute
+}
+
+// Replaces the values in the logged snapshot, a nil logger logs nothing
+func (l *responseBodyLogger) redact(values ...string) {
+	if l != nil {
+		l.redacted = append(l.redacted, values...)
+	}
+}
+
+func (l *responseBodyLogger) Write(p []byte) (int, error) {
+
+	if kept := l.limit - int64(len(l.snapshot)); kept > 0 {
+		if int64(len(p)) < kept {
+			kept = int64(len(p))
+		}
+		l.snapshot = append(l.snapshot, p[:kept]...)
+	}
+
+	n, err := l.ResponseWriter.Write(p)
+	l.size += int64(n)
+
+	return n, err
+}
+
+func (l *responseBodyLogger) Flush() {
+	if flusher, ok := l.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (l *responseBodyLogger) Unwrap() http.ResponseWriter {
+	return l.ResponseWriter
+}
+
+func (s *Server) logResponseBody(logger *responseBodyLogger, requestId string) {
+
+	if logger.size == 0 {
+		return
+	}
+
+	snapshot := logger.snapshot
+	for _, value := range logger.redacted {
+		if value != `` {
+			snapshot = bytes.Replace(snapshot, []byte(value), []byte(const_redacted_header_value), -1)
+		}
+	}
+	if s.responseBodyRedactor != nil {
+		snapshot = s.responseBodyRedactor(logger.Header().Get(`Content-Type`), snapshot)
+	}
+
+	text := string(snapshot)
+	if logger.size > logger.limit {
+		text = fmt.Sprintf("%s\n[body truncated to %d bytes]", snapshot, logger.limit)
+	}
+
+	Flog(FLOG_TYPE_DEBUG, fmt.Sprintf("%s Response body (%d bytes) :\n%s", requestId, logger.size, text))
+}
+
+func (s *Server) dumpRequest(request *http.Request, requestId string) {
+	Flog(FLOG_TYPE_DEBUG, fmt.Sprintf("%s Dumping request start", requestId))
+	fmt.Printf("%s", s.formatRequestDump(request))
+	Flog(FLOG_TYPE_DEBUG, fmt.Sprintf("%s Dumping request end", requestId))
+}
@@ -0,0 +1,158 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Request dump and response body logging tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestLogResponseBody(t *testing.T) {
+
+	body := strings.Repeat(`a`, 5000) + `TAIL`
+	redactor := func(contentType string, body []byte) []byte {
+		return regexp.MustCompile(`"password":"[^"]*"`).ReplaceAll(body, []byte(`"password":"***"`))
+	}
+
+	tests := []struct {
+		name      string
+		limit     int64
+		redactor  func(contentType string, body []byte) []byte
+		body      string
+		logged    []string
+		notLogged []string
+	}{
+		{`disabled`, 0, nil, body, nil, []string{`aaaa`}},
+		{`truncated`, 100, nil, body, []string{`(5004 bytes)`, strings.Repeat(`a`, 100) + "\n[body truncated to 100 bytes]"}, []string{strings.Repeat(`a`, 101), `TAIL`}},
+		{`whole`, 100, nil, `short`, []string{"(5 bytes) :\nshort"}, []string{`truncated`}},
+		{`redacted`, 100, redactor, `{"user":"joe","password":"secret"}`, []string{`"password":"***"`, `"user":"joe"`}, []string{`secret`}},
+	}
+
+	for _, test := range tests {
+		s := NewServer(`/`, `:0`)
+		s.NewEndpoint(`/body`, textResourceHandler(`GET`, test.body))
+		s.DebugEnableLogResponseBody(test.limit)
+		s.DebugSetResponseBodyRedactor(test.redactor)
+
+		var recorderBody *bytes.Buffer
+		logged := captureLog(func() {
+			recorderBody = serveTestRequest(s, `GET`, `/body`, nil, nil).Body
+		})
+
+		if recorderBody.String() != test.body {
+			t.Errorf(`%s : the response body was changed`, test.name)
+		}
+		for _, expected := range test.logged {
+			if !strings.Contains(logged, expected) {
+				t.Errorf(`%s : %q is not logged`, test.name, expected)
+			}
+		}
+		for _, unexpected := range test.notLogged {
+			if strings.Contains(logged, unexpected) {
+				t.Errorf(`%s : %q is logged`, test.name, unexpected)
+			}
+		}
+	}
+}
+
+func TestRequestDump(t *testing.T) {
+
+	body := strings.Repeat(`b`, 2000) + `TAIL`
+
+	tests := []struct {
+		name            string
+		redactedHeaders []string
+		bodyLimit       int64
+		dumped          []string
+		notDumped       []string
+	}{
+		{`default redaction`, nil, 0, []string{"POST /uploads?id=1 HTTP/1.1\n", "Authorization: [REDACTED]\n", "Cookie: [REDACTED]\n", "X-Api-Key: key-123\n"}, []string{`Bearer secret`, `session=abc`, `bbbb`}},
+		{`configured redaction`, []string{`x-api-key`}, 0, []string{"Authorization: Bearer secret\n", "X-Api-Key: [REDACTED]\n"}, []string{`key-123`}},
+		{`body snapshot`, nil, 16, []string{"\n\n" + strings.Repeat(`b`, 16) + "\n[body truncated to 16 bytes]"}, []string{strings.Repeat(`b`, 17), `TAIL`}},
+	}
+
+	for _, test := range tests {
+		s := NewServer(`/`, `:0`)
+		s.DebugSetRequestDumpBodyLimit(test.bodyLimit)
+		s.SetRedactedHeaders(test.redactedHeaders)
+
+		request := httptest.NewRequest(`POST`, `/uploads?id=1`, strings.NewReader(body))
+		request.Header.Set(`Authorization`, `Bearer secret`)
+		request.Header.Set(`Cookie`, `session=abc`)
+		request.Header.Set(`X-Api-Key`, `key-123`)
+
+		dump := string(s.formatRequestDump(request))
+
+		for _, expected := range test.dumped {
+			if !strings.Contains(dump, expected) {
+				t.Errorf(`%s : %q is not dumped in %q`, test.name, expected, dump)
+			}
+		}
+		for _, unexpected := range test.notDumped {
+			if strings.Contains(dump, unexpected) {
+				t.Errorf(`%s : %q is dumped`, test.name, unexpected)
+			}
+		}
+
+		// What was read for the snapshot is put back for the handlers
+		if read, _ := ioutil.ReadAll(request.Body); string(read) != body {
+			t.Errorf(`%s : %d bytes left in the body`, test.name, len(read))
+		}
+	}
+}
+
+func TestRequestDumpFormat(t *testing.T) {
+
+	tests := []struct {
+		name            string
+		method          string
+		target          string
+		body            string
+		logMatchedRoute bool
+		dump            string
+	}{
+		{`without body`, `GET`, `/users?limit=5`, ``, false, "GET /users?limit=5 HTTP/1.1\nHost: example.com\nRemote-Address: 192.0.2.1:1234\nAccept: application/json\nX-Tenant: acme\n"},
+		{`with body`, `PUT`, `/users/1`, `{"name":"bob"}`, false, "PUT /users/1 HTTP/1.1\nHost: example.com\nRemote-Address: 192.0.2.1:1234\nAccept: application/json\nX-Tenant: acme\n\n{\"name\":\"bob\"}\n"},
+		{`raw path kept out`, `GET`, `/users/jane@example.com`, ``, true, "GET [REDACTED] HTTP/1.1\nHost: example.com\nRemote-Address: 192.0.2.1:1234\nAccept: application/json\nX-Tenant: acme\n"},
+	}
+
+	for _, test := range tests {
+		s := NewServer(`/`, `:0`)
+		s.SetLogMatchedRoute(test.logMatchedRoute)
+
+		request := httptest.NewRequest(test.method, `http://example.com`+test.target, strings.NewReader(test.body))
+		request.Header.Set(`X-Tenant`, `acme`)
+		request.Header.Set(`Accept`, `application/json`)
+
+		if dump := string(s.formatRequestDump(request)); dump != test.dump {
+			t.Errorf(`%s : got %q, expected %q`, test.name, dump, test.dump)
+		}
+	}
+}
@@ -26,17 +26,35 @@
 
 package gorip
 
-import ()
+import (
+	"sync/atomic"
+)
 
 type endpoint struct {
 	route            string
 	resourceHandlers []ResourceHandler
+	disabled         int32 // accessed atomically, a disabled endpoint is kept registered but not served
+	middlewares      []scopedMiddleware
+	singleFlight     bool   // identical concurrent GET requests share one execution, see Server.EnableSingleFlight
+	version          string // API version of the route, see Server.NewVersionedEndpoint
 }
 
 func (e *endpoint) GetRoute() string {
 	return e.route
 }
 
+func (e *endpoint) IsEnabled() bool {
+	return atomic.LoadInt32(&e.disabled) == 0
+}
+
+func (e *endpoint) SetEnabled(enabled bool) {
+	if enabled {
+		atomic.StoreInt32(&e.disabled, 0)
+	} else {
+		atomic.StoreInt32(&e.disabled, 1)
+	}
+}
+
 func (e *endpoint) AddResource(rh ResourceHandler) {
 	e.resourceHandlers = append(e.resourceHandlers, rh)
 }
@@ -60,6 +78,11 @@ func (e *endpoint) FindMatchingResource(method string, contentTypeParser *conten
 				allContentTypeIn := v.ContentTypeIn
 				allContentTypeOut := v.ContentTypeOut
 
+				// Anything is accepted : the default OUT content type goes first
+				if acceptElement.contentType == `*/*` {
+					allContentTypeOut = v.GetContentTypeOutDefaultFirst()
+				}
+
 				// If OUT content type matches or 'matching everything' */* then the resource matches
 				for _, contentTypeOut := range allContentTypeOut {
 					if contentTypeOut == acceptElement.contentType || acceptElement.contentType == `*/*` {
@@ -79,11 +102,20 @@ func (e *endpoint) FindMatchingResource(method string, contentTypeParser *conten
 						if contentTypeParser.HasContentType() && len(allContentTypeIn) > 0 {
 							for _, contentTypeIn := range allContentTypeIn {
 								if contentTypeIn == contentTypeParser.GetContentType() {
+									// Copied : the loop variable is reused by the next iterations
+									matchedContentTypeIn := contentTypeIn
 									matchesIn = true
-									resultContentTypeIn = &contentTypeIn
+									resultContentTypeIn = &matchedContentTypeIn
+									break
 								}
 							}
 						}
+						// Resource accepts any body regardless of its declared content types : OK
+						if !matchesIn && v.AcceptAnyBody {
+							matchesIn = true
+							resultContentTypeIn = nil
+						}
+
 						if matchesIn {
 							return &v, resultContentTypeIn, resultContentTypeOut
 						}
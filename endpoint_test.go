@@ -0,0 +1,165 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Endpoint tests : resource handler matching.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAcceptAnyBody(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		contentTypeIn []string
+		acceptAnyBody bool
+		contentType   string
+		status        int
+	}{
+		{`raw body`, nil, true, `application/octet-stream`, http.StatusOK},
+		{`raw body without Content-Type`, nil, true, ``, http.StatusOK},
+		{`undeclared content type`, []string{`application/json`}, true, `application/x-signed`, http.StatusOK},
+		{`body not allowed`, nil, false, `application/octet-stream`, http.StatusBadRequest},
+		{`content type not consumed`, []string{`application/json`}, false, `application/x-signed`, http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		var contentTypeIn *string
+
+		s := NewServer(`/`, `:0`)
+		s.NewEndpoint(`/webhooks`, ResourceHandler{Method: `POST`, ContentTypeIn: test.contentTypeIn, ContentTypeOut: []string{`text/plain`}, AcceptAnyBody: test.acceptAnyBody, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			contentTypeIn = context.ContentTypeIn
+			return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBuffer(context.Body.Bytes())}
+		})})
+
+		recorder := serveTestRequest(s, `POST`, `/webhooks`, strings.NewReader(`signed payload`), map[string]string{`Content-Type`: test.contentType})
+
+		if recorder.Code != test.status {
+			t.Errorf(`%s : expected %d, got %d %q`, test.name, test.status, recorder.Code, recorder.Body.String())
+			continue
+		}
+		if test.status == http.StatusOK {
+			if recorder.Body.String() != `signed payload` {
+				t.Errorf(`%s : handler got %q`, test.name, recorder.Body.String())
+			}
+			if contentTypeIn != nil {
+				t.Errorf(`%s : ContentTypeIn %q, expected none`, test.name, *contentTypeIn)
+			}
+		}
+	}
+}
+
+func TestSetEndpointEnabled(t *testing.T) {
+
+	s := NewServer(`/`, `:0`)
+	s.EnableDocumentationEndpoint(`/doc`)
+	s.NewEndpoint(`/beta`, textResourceHandler(`GET`, `beta`))
+	s.NewEndpointForHost(`api.example.com`, `/beta`, textResourceHandler(`GET`, `api beta`))
+
+	tests := []struct {
+		name    string
+		enabled bool
+		status  int
+	}{
+		{`disabled`, false, http.StatusNotFound},
+		{`enabled again`, true, http.StatusOK},
+	}
+
+	for _, test := range tests {
+		if err := s.SetEndpointEnabled(`/beta`, test.enabled); err != nil {
+			t.Fatal(err)
+		}
+		for _, target := range []string{`/beta`, `http://api.example.com/beta`} {
+			if recorder := serveTestRequest(s, `GET`, target, nil, nil); recorder.Code != test.status {
+				t.Errorf(`%s : %s expected %d, got %d`, test.name, target, test.status, recorder.Code)
+			}
+		}
+		// Still documented
+		if recorder := serveTestRequest(s, `GET`, `/doc`, nil, nil); !strings.Contains(recorder.Body.String(), `<h2>/beta</h2>`) {
+			t.Errorf(`%s : /beta is not documented`, test.name)
+		}
+	}
+
+	if err := s.SetEndpointEnabled(`/unknown`, false); err == nil {
+		t.Error(`an unknown route was toggled`)
+	}
+}
+
+func TestDefaultContentTypeOut(t *testing.T) {
+
+	tests := []struct {
+		name               string
+		missingAcceptAsAny bool
+		defaultOut         string
+		accept             string
+		status             int
+		contentType        string
+	}{
+		{`any accepted`, false, ``, `*/*`, http.StatusOK, `application/json`},
+		{`any accepted with a default`, false, `application/xml`, `*/*`, http.StatusOK, `application/xml`},
+		{`explicit accept over the default`, false, `application/xml`, `application/json`, http.StatusOK, `application/json`},
+		{`missing accept rejected`, false, `application/xml`, ``, http.StatusBadRequest, `text/plain`},
+		{`missing accept as any`, true, `application/xml`, ``, http.StatusOK, `application/xml`},
+		{`missing accept as any without a default`, true, ``, ``, http.StatusOK, `application/json`},
+	}
+
+	for _, test := range tests {
+		s := NewServer(`/`, `:0`)
+		s.SetMissingAcceptAsAny(test.missingAcceptAsAny)
+		s.NewEndpoint(`/users/bob`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`application/json`, `application/xml`}, DefaultContentTypeOut: test.defaultOut, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			return NewResult(http.StatusOK, codecTestUser{Name: `bob`})
+		})})
+
+		recorder := serveTestRequest(s, `GET`, `/users/bob`, nil, map[string]string{`Accept`: test.accept})
+		if recorder.Code != test.status || recorder.Header().Get(`Content-Type`) != test.contentType {
+			t.Errorf(`%s : got %d as %q, expected %d as %q`, test.name, recorder.Code, recorder.Header().Get(`Content-Type`), test.status, test.contentType)
+		}
+	}
+}
+
+func TestNewEndpointRejectsUnrecognizedMethods(t *testing.T) {
+
+	tests := []struct {
+		method string
+		err    string
+	}{
+		{`GET`, ``},
+		{`PATCH`, ``},
+		{``, `Resource handler of endpoint /users has an unrecognized method '', expected one of GET, HEAD, POST, PUT, PATCH, DELETE, OPTIONS`},
+		{`get`, `Resource handler of endpoint /users has an unrecognized method 'get', expected one of GET, HEAD, POST, PUT, PATCH, DELETE, OPTIONS`},
+		{`FETCH`, `Resource handler of endpoint /users has an unrecognized method 'FETCH', expected one of GET, HEAD, POST, PUT, PATCH, DELETE, OPTIONS`},
+	}
+
+	for _, test := range tests {
+		s := NewServer(`/`, `:0`)
+		err := s.NewEndpoint(`/users`, textResourceHandler(test.method, `users`))
+		if (err == nil && test.err != ``) || (err != nil && err.Error() != test.err) {
+			t.Errorf(`%q : got %v, expected %q`, test.method, err, test.err)
+		}
+	}
+}
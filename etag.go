@@ -0,0 +1,84 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Automatic weak ETags and If-None-Match conditional GET requests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// Sets a weak ETag computed from the body on 200 GET responses not carrying one,
+// and answers 304 Not Modified when If-None-Match matches the ETag, handler provided or computed
+func (s *Server) EnableAutoETag(b bool) {
+	s.autoETagEnabled = b
+}
+
+// Weak ETag of a body
+func computeWeakETag(body []byte) string {
+	sum := sha1.Sum(body)
+	return `W/"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+// True if an If-None-Match header value matches the ETag, using the weak comparison
+func matchesIfNoneMatch(ifNoneMatch string, etag string) bool {
+
+	for _, candidate := range strings.Split(ifNoneMatch, `,`) {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == `*` || strings.TrimPrefix(candidate, `W/`) == strings.TrimPrefix(etag, `W/`) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Adds the ETag to a cacheable result, or turns it into a 304 when the client already has it
+func (s *Server) applyAutoETag(request *http.Request, result ResourceHandlerResult) ResourceHandlerResult {
+
+	if (request.Method != `GET` && request.Method != `HEAD`) || result.HttpStatus != http.StatusOK || result.Stream != nil {
+		return result
+	}
+
+	etag := result.Header.Get(`ETag`)
+	if etag == `` {
+		if result.Body == nil {
+			return result
+		}
+		etag = computeWeakETag(result.Body.Bytes())
+		result = result.withHeader(`ETag`, etag)
+	}
+
+	ifNoneMatch := request.Header.Get(`If-None-Match`)
+	if ifNoneMatch == `` || !matchesIfNoneMatch(ifNoneMatch, etag) {
+		return result
+	}
+
+	// Headers such as Cache-Control are kept, as they would have been sent with a 200
+	notModified := ResourceHandlerResult{HttpStatus: http.StatusNotModified, Raw: true, Header: result.Header}
+	return notModified.withHeader(`ETag`, etag)
+}
@@ -0,0 +1,79 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Automatic ETag tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+)
+
+func TestAutoETag(t *testing.T) {
+
+	etag := computeWeakETag([]byte(`report`))
+
+	tests := []struct {
+		name        string
+		enabled     bool
+		method      string
+		handlerETag string
+		ifNoneMatch string
+		status      int
+		etag        string
+		body        string
+	}{
+		{`disabled`, false, `GET`, ``, etag, http.StatusOK, ``, `report`},
+		{`generated`, true, `GET`, ``, ``, http.StatusOK, etag, `report`},
+		{`not modified`, true, `GET`, ``, etag, http.StatusNotModified, etag, ``},
+		{`strong form matches weakly`, true, `GET`, ``, etag[2:], http.StatusNotModified, etag, ``},
+		{`one of several`, true, `GET`, ``, `"other", ` + etag, http.StatusNotModified, etag, ``},
+		{`any`, true, `GET`, ``, `*`, http.StatusNotModified, etag, ``},
+		{`modified`, true, `GET`, ``, `W/"other"`, http.StatusOK, etag, `report`},
+		{`handler etag kept`, true, `GET`, `"v2"`, ``, http.StatusOK, `"v2"`, `report`},
+		{`handler etag matched`, true, `GET`, `"v2"`, `"v2"`, http.StatusNotModified, `"v2"`, ``},
+		{`not a GET`, true, `POST`, ``, etag, http.StatusOK, ``, `report`},
+	}
+
+	for _, test := range tests {
+		s := NewServer(`/`, `:0`)
+		s.EnableAutoETag(test.enabled)
+		s.NewEndpoint(`/reports`, ResourceHandler{Method: test.method, ContentTypeIn: []string{}, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			result := ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(`report`)}
+			if test.handlerETag != `` {
+				result = result.withHeader(`ETag`, test.handlerETag)
+			}
+			return result.withHeader(`Cache-Control`, `max-age=60`)
+		})})
+
+		recorder := serveTestRequest(s, test.method, `/reports`, nil, map[string]string{`If-None-Match`: test.ifNoneMatch})
+		if recorder.Code != test.status || recorder.Header().Get(`ETag`) != test.etag || recorder.Body.String() != test.body {
+			t.Errorf(`%s : got %d with ETag %q and %q, expected %d with %q and %q`, test.name, recorder.Code, recorder.Header().Get(`ETag`), recorder.Body.String(), test.status, test.etag, test.body)
+		}
+		if recorder.Header().Get(`Cache-Control`) != `max-age=60` {
+			t.Errorf(`%s : Cache-Control was dropped`, test.name)
+		}
+	}
+}
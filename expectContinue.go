@@ -0,0 +1,66 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Expect: 100-continue policy : decides whether the client may send its body.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Registers a function deciding, before the body is read, whether a request sent with Expect: 100-continue may send it
+// A nil error lets the client send the body, an *HTTPError is rendered with its status, e.g. 401 or 403,
+// and any other error as a 417 Expectation Failed ; rejected bodies are never read
+// The context holds the headers, route variables and negotiated content types, but not the query parameters nor the body yet
+func (s *Server) SetExpectContinueHook(hook func(ctx *ResourceHandlerContext) error) {
+	s.expectContinueHook = hook
+}
+
+func isExpectContinue(header http.Header) bool {
+	return strings.EqualFold(header.Get(`Expect`), `100-continue`)
+}
+
+// Renders the rejection of the hook, if any, returns true if the request was rejected
+func (s *Server) rejectExpectContinue(writer http.ResponseWriter, ctx *ResourceHandlerContext, requestId string) bool {
+
+	err := s.expectContinueHook(ctx)
+	if err == nil {
+		return false
+	}
+
+	Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Expect: 100-continue rejected : %s", requestId, err.Error()))
+
+	var httpError *HTTPError
+	if errors.As(err, &httpError) {
+		s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: httpError.Status, Body: bytes.NewBufferString(httpError.Message)}, `text/plain`, requestId)
+	} else {
+		s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusExpectationFailed, Body: bytes.NewBufferString(err.Error())}, `text/plain`, requestId)
+	}
+
+	return true
+}
@@ -0,0 +1,83 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Expect: 100-continue hook tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestExpectContinueHook(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		expect        string
+		authorization string
+		length        int
+		status        int
+		read          bool
+	}{
+		{`accepted`, `100-continue`, `Bearer admin`, 1024, http.StatusOK, true},
+		{`too large`, `100-continue`, `Bearer admin`, 1 << 30, http.StatusExpectationFailed, false},
+		{`no credentials`, `100-continue`, ``, 1024, http.StatusUnauthorized, false},
+		{`wrapped http error`, `100-continue`, `Bearer guest`, 1024, http.StatusForbidden, false},
+		{`without expect`, ``, ``, 1024, http.StatusOK, true},
+	}
+
+	handler := textResourceHandler(`PUT`, `uploaded`)
+	handler.ContentTypeIn = []string{`application/octet-stream`}
+
+	s := NewServer(`/`, `:0`)
+	s.NewEndpoint(`/uploads`, handler)
+	s.SetExpectContinueHook(func(ctx *ResourceHandlerContext) error {
+		switch authorization := ctx.Header.Get(`Authorization`); {
+		case authorization == ``:
+			return NewHTTPError(http.StatusUnauthorized, `Unauthorized`)
+		case authorization != `Bearer admin`:
+			return fmt.Errorf(`Upload refused : %w`, NewHTTPError(http.StatusForbidden, `Forbidden`))
+		}
+		if length, _ := strconv.Atoi(ctx.Header.Get(`Content-Length`)); length > 1<<20 {
+			return errors.New(`Upload too large`)
+		}
+		return nil
+	})
+
+	for _, test := range tests {
+		body := &watchedBody{Reader: strings.NewReader(`data`)}
+		recorder := serveTestRequest(s, `PUT`, `/uploads`, body, map[string]string{`Content-Type`: `application/octet-stream`, `Expect`: test.expect, `Authorization`: test.authorization, `Content-Length`: strconv.Itoa(test.length)})
+
+		if recorder.Code != test.status {
+			t.Errorf(`%s : expected %d, got %d %q`, test.name, test.status, recorder.Code, recorder.Body.String())
+		}
+		if body.read != test.read {
+			t.Errorf(`%s : body read %t, expected %t`, test.name, body.read, test.read)
+		}
+	}
+}
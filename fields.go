@@ -0,0 +1,117 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Sparse fieldsets : JSON responses restricted to the fields requested by the client.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	const_sparse_fieldsets_query_parameter = `fields`
+)
+
+// Keeps the requested top-level keys of a JSON object, or of each object of a JSON array
+// Any other JSON value is returned as is
+func selectJSONFields(body []byte, fields []string) ([]byte, error) {
+
+	trimmedBody := bytes.TrimSpace(body)
+	if len(trimmedBody) == 0 {
+		return body, nil
+	}
+
+	switch trimmedBody[0] {
+
+	case '{':
+		return selectJSONObjectFields(trimmedBody, fields)
+
+	case '[':
+		var elements []json.RawMessage
+		err := json.Unmarshal(trimmedBody, &elements)
+		if err != nil {
+			return nil, err
+		}
+		for i, element := range elements {
+			if len(element) > 0 && element[0] == '{' {
+				elements[i], err = selectJSONObjectFields(element, fields)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+		return json.Marshal(elements)
+	}
+
+	return body, nil
+}
+
+func selectJSONObjectFields(object []byte, fields []string) ([]byte, error) {
+
+	var members map[string]json.RawMessage
+	err := json.Unmarshal(object, &members)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make(map[string]json.RawMessage)
+	for _, field := range fields {
+		if value, ok := members[field]; ok {
+			selected[field] = value
+		}
+	}
+
+	return json.Marshal(selected)
+}
+
+// Applies the fields query parameter, e.g. ?fields=id,name, to a JSON result of a resource handler with SparseFieldsets
+func (s *Server) applySparseFieldsets(result *ResourceHandlerResult, contentType string, fieldsValue string, requestId string) {
+
+	if contentType != `application/json` || result.Body == nil || result.Stream != nil {
+		return
+	}
+
+	fields := []string{}
+	for _, field := range strings.Split(fieldsValue, `,`) {
+		field = strings.TrimSpace(field)
+		if field != `` {
+			fields = append(fields, field)
+		}
+	}
+
+	if len(fields) == 0 {
+		return
+	}
+
+	selectedBody, err := selectJSONFields(result.Body.Bytes(), fields)
+	if err != nil {
+		Flog(FLOG_TYPE_WARNING, fmt.Sprintf("%s Could not select fields of the response : %s", requestId, err.Error()))
+		return
+	}
+
+	result.Body = bytes.NewBuffer(selectedBody)
+}
@@ -0,0 +1,73 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Sparse fieldsets tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type fieldsTestUser struct {
+	Id    int    `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+func TestSparseFieldsets(t *testing.T) {
+
+	tests := []struct {
+		name            string
+		sparseFieldsets bool
+		path            string
+		body            string
+	}{
+		{`object`, true, `/users/1?fields=id,name`, `{"id":1,"name":"bob"}`},
+		{`array`, true, `/users?fields=email`, `[{"email":"bob@example.com"},{"email":"alice@example.com"}]`},
+		{`spaces and unknown field`, true, `/users/1?fields=%20name%20,age`, `{"name":"bob"}`},
+		{`no fields`, true, `/users/1`, `{"id":1,"name":"bob","email":"bob@example.com"}`},
+		{`empty fields`, true, `/users/1?fields=,`, `{"id":1,"name":"bob","email":"bob@example.com"}`},
+		{`not opted in`, false, `/users/1?fields=id`, `{"id":1,"name":"bob","email":"bob@example.com"}`},
+	}
+
+	bob := fieldsTestUser{Id: 1, Name: `bob`, Email: `bob@example.com`}
+	alice := fieldsTestUser{Id: 2, Name: `alice`, Email: `alice@example.com`}
+
+	for _, test := range tests {
+		s := NewServer(`/`, `:0`)
+		s.NewEndpoint(`/users/1`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`application/json`}, SparseFieldsets: test.sparseFieldsets, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			return NewResult(http.StatusOK, bob)
+		})})
+		s.NewEndpoint(`/users`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`application/json`}, SparseFieldsets: test.sparseFieldsets, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			return NewResult(http.StatusOK, []fieldsTestUser{bob, alice})
+		})})
+
+		recorder := serveTestRequest(s, `GET`, test.path, nil, nil)
+		if recorder.Code != http.StatusOK || strings.TrimSpace(recorder.Body.String()) != test.body {
+			t.Errorf(`%s : got %d %q, expected %q`, test.name, recorder.Code, recorder.Body.String(), test.body)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Codec for application/x-www-form-urlencoded bodies.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Maps form fields to struct fields named by a form tag, or else a json tag, or else the field name
+// Supported field kinds are string, bool, integers, floats and slices of them ; a *map[string]string is also accepted
+type FormCodec struct {
+}
+
+func (c *FormCodec) Marshal(v interface{}) ([]byte, error) {
+
+	values := url.Values{}
+
+	// Nil, as an interface or a typed pointer : nothing to encode
+	value := reflect.Indirect(reflect.ValueOf(v))
+	if !value.IsValid() {
+		return []byte{}, nil
+	}
+
+	switch value.Kind() {
+
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return nil, errors.New(fmt.Sprintf(`Can not encode %s as a form`, value.Type().String()))
+		}
+		for _, key := range value.MapKeys() {
+			values.Set(key.String(), fmt.Sprint(value.MapIndex(key).Interface()))
+		}
+
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			name, ok := formFieldName(value.Type().Field(i))
+			if !ok {
+				continue
+			}
+			field := value.Field(i)
+			if field.Kind() == reflect.Slice {
+				for j := 0; j < field.Len(); j++ {
+					values.Add(name, fmt.Sprint(field.Index(j).Interface()))
+				}
+			} else {
+				values.Set(name, fmt.Sprint(field.Interface()))
+			}
+		}
+
+	default:
+		return nil, errors.New(fmt.Sprintf(`Can not encode %s as a form`, value.Type().String()))
+	}
+
+	return []byte(values.Encode()), nil
+}
+
+func (c *FormCodec) Unmarshal(data []byte, v interface{}) error {
+
+	values, err := url.ParseQuery(string(data))
+	if err != nil {
+		return err
+	}
+
+	pointer := reflect.ValueOf(v)
+	if pointer.Kind() != reflect.Ptr || pointer.IsNil() {
+		return errors.New(`Form can only be decoded into a non nil pointer`)
+	}
+
+	value := pointer.Elem()
+	switch value.Kind() {
+
+	case reflect.Map:
+		if value.Type() != reflect.TypeOf(map[string]string{}) {
+			return errors.New(fmt.Sprintf(`Can not decode a form into %s`, value.Type().String()))
+		}
+		if value.IsNil() {
+			value.Set(reflect.MakeMap(value.Type()))
+		}
+		for key := range values {
+			value.SetMapIndex(reflect.ValueOf(key), reflect.ValueOf(values.Get(key)))
+		}
+
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			name, ok := formFieldName(value.Type().Field(i))
+			if !ok {
+				continue
+			}
+			fieldValues, ok := values[name]
+			if !ok || len(fieldValues) == 0 {
+				continue
+			}
+			err := setFormField(value.Field(i), fieldValues)
+			if err != nil {
+				return errors.New(fmt.Sprintf(`Form field %s : %s`, name, err.Error()))
+			}
+		}
+
+	default:
+		return errors.New(fmt.Sprintf(`Can not decode a form into %s`, value.Type().String()))
+	}
+
+	return nil
+}
+
+// Form name of an exported struct field, false if the field is unexported or tagged "-"
+func formFieldName(field reflect.StructField) (string, bool) {
+
+	if field.PkgPath != `` {
+		return ``, false
+	}
+
+	for _, tagName := range []string{`form`, `json`} {
+		tag := strings.Split(field.Tag.Get(tagName), `,`)[0]
+		if tag == `-` {
+			return ``, false
+		}
+		if tag != `` {
+			return tag, true
+		}
+	}
+
+	return field.Name, true
+}
+
+func setFormField(field reflect.Value, fieldValues []string) error {
+
+	if field.Kind() == reflect.Slice {
+		slice := reflect.MakeSlice(field.Type(), len(fieldValues), len(fieldValues))
+		for i, fieldValue := range fieldValues {
+			err := setFormValue(slice.Index(i), fieldValue)
+			if err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+		return nil
+	}
+
+	return setFormValue(field, fieldValues[0])
+}
+
+func setFormValue(field reflect.Value, fieldValue string) error {
+
+	switch field.Kind() {
+
+	case reflect.String:
+		field.SetString(fieldValue)
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(fieldValue)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(fieldValue, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(i)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(fieldValue, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(u)
+
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(fieldValue, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+
+	default:
+		return errors.New(fmt.Sprintf(`Unsupported kind %s`, field.Kind().String()))
+	}
+
+	return nil
+}
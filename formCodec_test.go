@@ -0,0 +1,144 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Form codec and multi format decoding tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type formTestUser struct {
+	Name     string   `json:"name"`
+	Age      int      `form:"age" json:"years"`
+	Admin    bool     `json:"admin"`
+	Tags     []string `json:"tags"`
+	Password string   `json:"-"`
+}
+
+func TestDecodeJSONAndForm(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+	}{
+		{`json`, `application/json`, `{"name":"bob","years":42,"admin":true,"tags":["a","b"]}`, http.StatusCreated},
+		{`form`, `application/x-www-form-urlencoded`, `name=bob&age=42&admin=true&tags=a&tags=b&Password=secret`, http.StatusCreated},
+		{`malformed json`, `application/json`, `{"name":`, http.StatusBadRequest},
+		{`malformed form field`, `application/x-www-form-urlencoded`, `name=bob&age=old`, http.StatusBadRequest},
+	}
+
+	expected := formTestUser{Name: `bob`, Age: 42, Admin: true, Tags: []string{`a`, `b`}}
+
+	for _, test := range tests {
+		var decoded formTestUser
+
+		s := NewServer(`/`, `:0`)
+		s.NewEndpoint(`/users`, ResourceHandler{Method: `POST`, ContentTypeIn: []string{`application/json`, `application/x-www-form-urlencoded`}, ContentTypeOut: []string{`text/plain`}, Implementation: ErrorHandlerFunc(func(context *ResourceHandlerContext) (ResourceHandlerResult, error) {
+			if err := context.Decode(&decoded); err != nil {
+				return ResourceHandlerResult{}, err
+			}
+			return ResourceHandlerResult{HttpStatus: http.StatusCreated}, nil
+		})})
+
+		recorder := serveTestRequest(s, `POST`, `/users`, strings.NewReader(test.body), map[string]string{`Content-Type`: test.contentType})
+		if recorder.Code != test.status {
+			t.Errorf(`%s : expected %d, got %d %q`, test.name, test.status, recorder.Code, recorder.Body.String())
+		}
+		if test.status == http.StatusCreated && !reflect.DeepEqual(decoded, expected) {
+			t.Errorf(`%s : decoded %+v, expected %+v`, test.name, decoded, expected)
+		}
+	}
+}
+
+func TestFormCodec(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		value interface{}
+		form  string
+	}{
+		{`struct`, formTestUser{Name: `bob`, Age: 42, Tags: []string{`a`, `b`}, Password: `secret`}, `admin=false&age=42&name=bob&tags=a&tags=b`},
+		{`map`, map[string]string{`name`: `bob`, `city`: `Paris`}, `city=Paris&name=bob`},
+	}
+
+	codec := &FormCodec{}
+
+	for _, test := range tests {
+		form, err := codec.Marshal(test.value)
+		if err != nil || string(form) != test.form {
+			t.Errorf(`%s : got %q %v, expected %q`, test.name, form, err, test.form)
+		}
+
+		decoded := reflect.New(reflect.TypeOf(test.value))
+		if err := codec.Unmarshal(form, decoded.Interface()); err != nil {
+			t.Errorf(`%s : %s`, test.name, err.Error())
+			continue
+		}
+		expected := test.value
+		if user, ok := test.value.(formTestUser); ok {
+			user.Password = ``
+			expected = user
+		}
+		if !reflect.DeepEqual(decoded.Elem().Interface(), expected) {
+			t.Errorf(`%s : decoded %+v, expected %+v`, test.name, decoded.Elem().Interface(), expected)
+		}
+	}
+
+	// Only into a pointer to a struct or a map[string]string
+	for _, v := range []interface{}{formTestUser{}, new(int), new(map[string]int)} {
+		if err := codec.Unmarshal([]byte(`name=bob`), v); err == nil {
+			t.Errorf(`%T : decoded`, v)
+		}
+	}
+}
+
+func TestFormCodecMarshalNil(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		value interface{}
+		form  string
+		fails bool
+	}{
+		{`nil`, nil, ``, false},
+		{`typed nil pointer`, (*formTestUser)(nil), ``, false},
+		{`nil map`, map[string]string(nil), ``, false},
+		{`int`, 42, ``, true},
+	}
+
+	codec := &FormCodec{}
+
+	for _, test := range tests {
+		form, err := codec.Marshal(test.value)
+		if (err != nil) != test.fails || string(form) != test.form {
+			t.Errorf(`%s : got %q %v, expected %q`, test.name, form, err, test.form)
+		}
+	}
+}
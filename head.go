@@ -0,0 +1,119 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Automatic HEAD responses, served by the GET resource handler without body.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Implemented by a GET resource handler implementation able to tell its body length cheaply,
+// automatic HEAD requests are then answered without executing it, once the endpoint middlewares let them through
+// Not used when automatic ETags are enabled, as the ETag is computed from the body
+type ContentLengthHinter interface {
+	ContentLength(context *ResourceHandlerContext) (length int64, ok bool)
+}
+
+// Answers HEAD requests with the GET resource handler on endpoints not implementing HEAD themselves
+func (s *Server) EnableAutomaticHead(b bool) {
+	s.automaticHeadEnabled = b
+}
+
+// True if none of the endpoint resource handlers implements HEAD itself
+func (e *endpoint) needsAutomaticHead() bool {
+	for _, r := range e.resourceHandlers {
+		if r.Method == `HEAD` {
+			return false
+		}
+	}
+	return true
+}
+
+// Answers from the implementation hint if it has one, returns false when the GET handler must be executed
+// Runs in place of the implementation, so that the middlewares still decide whether the resource may be disclosed
+func contentLengthHintResult(resource *ResourceHandler, context *ResourceHandlerContext, requestId string) (ResourceHandlerResult, bool) {
+
+	hinter, ok := resource.Implementation.(ContentLengthHinter)
+	if !ok {
+		return ResourceHandlerResult{}, false
+	}
+
+	length, ok := hinter.ContentLength(context)
+	if !ok {
+		return ResourceHandlerResult{}, false
+	}
+
+	context.hintedContentLength = &length
+
+	Flog(FLOG_TYPE_INFO, fmt.Sprintf("%s Response result from HEAD Content-Length hint", requestId))
+
+	return ResourceHandlerResult{HttpStatus: http.StatusOK}, true
+}
+
+// True if the result is the one answered from the Content-Length hint, not replaced by a middleware
+func isContentLengthHintResult(result *ResourceHandlerResult, context *ResourceHandlerContext) bool {
+	return context.hintedContentLength != nil && result.HttpStatus == http.StatusOK && result.Body == nil && result.Payload == nil && result.Stream == nil
+}
+
+// Renders the headers of the result with the hinted Content-Length, there is no body to measure
+func (s *Server) renderContentLengthHint(writer http.ResponseWriter, result *ResourceHandlerResult, context *ResourceHandlerContext, requestId string) {
+
+	for name, values := range result.Header {
+		writer.Header()[name] = values
+	}
+
+	length := *context.hintedContentLength
+	writer.Header().Set(`Content-Length`, strconv.FormatInt(length, 10))
+	if length > 0 {
+		writer.Header().Set(`Content-Type`, context.ResponseContentType())
+	}
+	writer.WriteHeader(result.HttpStatus)
+
+	Flog(FLOG_TYPE_INFO, fmt.Sprintf("%s Response result %s", requestId, TermColorEscape(strconv.Itoa(result.HttpStatus), TERM_COLOR_GREEN)))
+
+	context.renderedResult = result
+}
+
+// Keeps the headers, Content-Length included, and drops the body written for a HEAD request,
+// so that the length of the result body is reported without the connection rejecting the write
+type headResponseWriter struct {
+	http.ResponseWriter
+}
+
+func (w *headResponseWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func (w *headResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *headResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,159 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Automatic HEAD tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+)
+
+// GET implementation counting its executions, with an optional Content-Length hint
+type hintedTestImplementation struct {
+	hint     int64
+	hinted   bool
+	executed int
+}
+
+func (i *hintedTestImplementation) Execute(context *ResourceHandlerContext) ResourceHandlerResult {
+	i.executed++
+	return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(`expensive report`)}
+}
+
+func (i *hintedTestImplementation) ContentLength(context *ResourceHandlerContext) (int64, bool) {
+	return i.hint, i.hinted
+}
+
+func TestAutomaticHead(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		method        string
+		hinted        bool
+		status        int
+		contentLength string
+		body          string
+		executed      int
+	}{
+		{`get`, `GET`, true, http.StatusOK, `16`, `expensive report`, 1},
+		{`head with a hint`, `HEAD`, true, http.StatusOK, `42`, ``, 0},
+		{`head without a hint`, `HEAD`, false, http.StatusOK, `16`, ``, 1},
+	}
+
+	for _, test := range tests {
+		implementation := &hintedTestImplementation{hint: 42, hinted: test.hinted}
+
+		s := NewServer(`/`, `:0`)
+		s.EnableAutomaticHead(true)
+		s.NewEndpoint(`/reports`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/plain`}, Implementation: implementation})
+
+		recorder := serveTestRequest(s, test.method, `/reports`, nil, nil)
+		if recorder.Code != test.status || recorder.Header().Get(`Content-Length`) != test.contentLength || recorder.Body.String() != test.body {
+			t.Errorf(`%s : got %d with Content-Length %q and %q, expected %d with %q and %q`, test.name, recorder.Code, recorder.Header().Get(`Content-Length`), recorder.Body.String(), test.status, test.contentLength, test.body)
+		}
+		if implementation.executed != test.executed {
+			t.Errorf(`%s : executed %d times, expected %d`, test.name, implementation.executed, test.executed)
+		}
+	}
+}
+
+func TestHeadContentLengthHintBehindMiddlewares(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		method        string
+		authorization string
+		autoETag      bool
+		status        int
+		contentLength string
+		cacheControl  string
+		executed      int
+	}{
+		{`anonymous get`, `GET`, ``, false, http.StatusUnauthorized, `12`, ``, 0},
+		{`anonymous head`, `HEAD`, ``, false, http.StatusUnauthorized, `12`, ``, 0},
+		{`authorized head`, `HEAD`, `Bearer alice`, false, http.StatusOK, `42`, `private`, 0},
+		{`authorized head with automatic etags`, `HEAD`, `Bearer alice`, true, http.StatusOK, `16`, `private`, 1},
+	}
+
+	for _, test := range tests {
+		implementation := &hintedTestImplementation{hint: 42, hinted: true}
+
+		s := NewServer(`/`, `:0`)
+		s.EnableAutomaticHead(true)
+		s.EnableAutoETag(test.autoETag)
+		s.NewEndpoint(`/reports`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/plain`}, CacheControl: &CacheControl{Private: true}, Implementation: implementation})
+		s.UseOnEndpoint(`/reports`, func(next ResourceHandlerImplementation) ResourceHandlerImplementation {
+			return ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+				if context.Header.Get(`Authorization`) == `` {
+					return ResourceHandlerResult{HttpStatus: http.StatusUnauthorized, Body: bytes.NewBufferString(`Unauthorized`)}
+				}
+				return next.Execute(context)
+			})
+		})
+
+		recorder := serveTestRequest(s, test.method, `/reports`, nil, map[string]string{`Authorization`: test.authorization})
+		if recorder.Code != test.status || recorder.Header().Get(`Content-Length`) != test.contentLength || recorder.Header().Get(`Cache-Control`) != test.cacheControl {
+			t.Errorf(`%s : got %d with Content-Length %q and Cache-Control %q, expected %d with %q and %q`, test.name, recorder.Code, recorder.Header().Get(`Content-Length`), recorder.Header().Get(`Cache-Control`), test.status, test.contentLength, test.cacheControl)
+		}
+		if implementation.executed != test.executed {
+			t.Errorf(`%s : executed %d times, expected %d`, test.name, implementation.executed, test.executed)
+		}
+	}
+}
+
+func TestHeadContentLengthMatchesGet(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		automatic bool
+	}{
+		{`automatic head`, true},
+		{`explicit head`, false},
+	}
+
+	report := func(context *ResourceHandlerContext) ResourceHandlerResult {
+		return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBuffer([]byte(`a fixed size report body`))}
+	}
+
+	for _, test := range tests {
+		s := NewServer(`/`, `:0`)
+		s.EnableAutomaticHead(test.automatic)
+		handlers := []ResourceHandler{{Method: `GET`, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(report)}}
+		if !test.automatic {
+			handlers = append(handlers, ResourceHandler{Method: `HEAD`, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(report)})
+		}
+		s.NewEndpoint(`/reports`, handlers...)
+
+		get := serveTestRequest(s, `GET`, `/reports`, nil, nil)
+		head := serveTestRequest(s, `HEAD`, `/reports`, nil, nil)
+		if head.Code != http.StatusOK || head.Header().Get(`Content-Length`) != get.Header().Get(`Content-Length`) || head.Body.Len() != 0 {
+			t.Errorf(`%s : got %d with Content-Length %q and %d body bytes, expected %d with %q and none`, test.name, head.Code, head.Header().Get(`Content-Length`), head.Body.Len(), http.StatusOK, get.Header().Get(`Content-Length`))
+		}
+		if get.Header().Get(`Content-Length`) != `24` {
+			t.Errorf(`%s : GET Content-Length %q, expected %q`, test.name, get.Header().Get(`Content-Length`), `24`)
+		}
+	}
+}
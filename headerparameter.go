@@ -0,0 +1,56 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Header parameters are request headers declared and validated by a resource handler.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"fmt"
+	"github.com/sigu-399/goformatvalidation"
+	"net/http"
+)
+
+type HeaderParameter struct {
+	Required        bool
+	FormatValidator goformatvalidation.Validator
+}
+
+// Checks a request header against its declaration, returns a description of the problem or an empty string
+func (h *HeaderParameter) Validate(name string, header http.Header) string {
+
+	value := header.Get(name)
+
+	if value == `` {
+		if h.Required {
+			return fmt.Sprintf("Header %s is required", name)
+		}
+		return ``
+	}
+
+	if h.FormatValidator != nil && !h.FormatValidator.IsValid(value) {
+		return fmt.Sprintf("Invalid Header %s, %s", name, h.FormatValidator.GetErrorMessage())
+	}
+
+	return ``
+}
@@ -0,0 +1,75 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Header parameter tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHeaderParametersAreCheckedBeforeTheBody(t *testing.T) {
+
+	handler := textResourceHandler(`POST`, `created`)
+	handler.ContentTypeIn = []string{`text/plain`}
+	handler.HeaderParameters = map[string]HeaderParameter{
+		`X-Tenant`:     {Required: true},
+		`X-Request-Id`: {FormatValidator: NewUUIDValidator()},
+	}
+
+	s := NewServer(`/`, `:0`)
+	s.NewEndpoint(`/items`, handler)
+
+	tests := []struct {
+		name   string
+		header map[string]string
+		status int
+		body   string
+		read   bool
+	}{
+		{`valid`, map[string]string{`X-Tenant`: `acme`, `X-Request-Id`: `0b5f8d3e-5b1a-4c2e-9d7f-2f1e3c4b5a69`}, http.StatusOK, `created`, true},
+		{`optional header missing`, map[string]string{`X-Tenant`: `acme`}, http.StatusOK, `created`, true},
+		{`required header missing`, map[string]string{}, http.StatusBadRequest, `Header X-Tenant is required`, false},
+		{`invalid header`, map[string]string{`X-Tenant`: `acme`, `X-Request-Id`: `42`}, http.StatusBadRequest, `Invalid Header X-Request-Id, must be a valid UUID`, false},
+	}
+
+	for _, test := range tests {
+		header := map[string]string{`Content-Type`: `text/plain`}
+		for name, value := range test.header {
+			header[name] = value
+		}
+		body := &watchedBody{Reader: strings.NewReader(`item`)}
+
+		recorder := serveTestRequest(s, `POST`, `/items`, body, header)
+
+		if recorder.Code != test.status || recorder.Body.String() != test.body {
+			t.Errorf(`%s : got %d %q, expected %d %q`, test.name, recorder.Code, recorder.Body.String(), test.status, test.body)
+		}
+		if body.read != test.read {
+			t.Errorf(`%s : body read %t, expected %t`, test.name, body.read, test.read)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Host based routing : endpoints scoped to an exact or wildcard host.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+const (
+	const_host_wildcard_prefix = `*.`
+)
+
+// A router tree serving the requests of a given host pattern
+type hostRouter struct {
+	host   string
+	router *router
+}
+
+// Adds an endpoint only served to requests whose Host matches host,
+// host is either exact ( api.example.com ) or a wildcard on subdomains ( *.example.com )
+func (s *Server) NewEndpointForHost(host string, route string, resourceHandlers ...ResourceHandler) error {
+
+	host = strings.ToLower(strings.TrimSpace(host))
+
+	if host == `` || host == const_host_wildcard_prefix || (strings.Contains(host, `*`) && !strings.HasPrefix(host, const_host_wildcard_prefix)) {
+		return s.recordRegistrationError(errors.New(fmt.Sprintf(`Invalid host pattern '%s'`, host)))
+	}
+
+	var r *router
+	for _, hr := range s.hostRouters {
+		if hr.host == host {
+			r = hr.router
+		}
+	}
+
+	if r == nil {
+		r = newRouter()
+		// Route variable types are shared by all the routers of a server
+		r.RouteVariableTypes = s.router.RouteVariableTypes
+		s.hostRouters = append(s.hostRouters, hostRouter{host: host, router: r})
+	}
+
+	Flog(FLOG_TYPE_INFO, fmt.Sprintf("Adding endpoint for host %s\n", TermColorEscape(host, TERM_COLOR_BLUE)))
+
+	return s.newEndpointOnRouter(r, route, resourceHandlers...)
+}
+
+// Picks the router for the request host : exact hosts first, then wildcards, then the default router
+func (s *Server) routerForHost(requestHost string) *router {
+
+	if len(s.hostRouters) == 0 {
+		return s.router
+	}
+
+	host := strings.ToLower(requestHost)
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	for _, hr := range s.hostRouters {
+		if hr.host == host {
+			return hr.router
+		}
+	}
+
+	for _, hr := range s.hostRouters {
+		if strings.HasPrefix(hr.host, const_host_wildcard_prefix) {
+			suffix := hr.host[len(const_host_wildcard_prefix)-1:]
+			if strings.HasSuffix(host, suffix) && len(host) > len(suffix) {
+				return hr.router
+			}
+		}
+	}
+
+	return s.router
+}
@@ -0,0 +1,74 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Host based routing tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestHostRouting(t *testing.T) {
+
+	s := NewServer(`/`, `:0`)
+	s.NewEndpoint(`/users`, textResourceHandler(`GET`, `default`))
+	s.NewEndpointForHost(`api.example.com`, `/users`, textResourceHandler(`GET`, `api`))
+	s.NewEndpointForHost(`*.example.com`, `/users`, textResourceHandler(`GET`, `tenant`))
+	s.NewEndpointForHost(`*.example.com`, `/tenants`, textResourceHandler(`GET`, `tenants`))
+
+	tests := []struct {
+		target string
+		status int
+		body   string
+	}{
+		{`http://api.example.com/users`, http.StatusOK, `api`},
+		{`http://API.example.com:8080/users`, http.StatusOK, `api`},
+		{`http://acme.example.com/users`, http.StatusOK, `tenant`},
+		{`http://acme.example.com/tenants`, http.StatusOK, `tenants`},
+		{`http://example.com/users`, http.StatusOK, `default`},
+		{`http://other.org/users`, http.StatusOK, `default`},
+		{`http://other.org/tenants`, http.StatusNotFound, ``},
+	}
+
+	for _, test := range tests {
+		recorder := serveTestRequest(s, `GET`, test.target, nil, nil)
+		if recorder.Code != test.status {
+			t.Errorf(`%s : expected %d, got %d`, test.target, test.status, recorder.Code)
+		}
+		if test.status == http.StatusOK && recorder.Body.String() != test.body {
+			t.Errorf(`%s : served %q, expected %q`, test.target, recorder.Body.String(), test.body)
+		}
+	}
+}
+
+func TestNewEndpointForHostRejectsInvalidHosts(t *testing.T) {
+
+	for _, host := range []string{``, `*.`, `api.*.com`, `*example.com`} {
+		s := NewServer(`/`, `:0`)
+		if err := s.NewEndpointForHost(host, `/users`, textResourceHandler(`GET`, `users`)); err == nil {
+			t.Errorf(`%q : accepted`, host)
+		}
+	}
+}
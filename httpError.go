@@ -0,0 +1,177 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Errors returned by resource handlers, mapped to an HTTP status by the server.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Error carrying the HTTP status to respond with
+type HTTPError struct {
+	Status  int
+	Message string
+}
+
+func NewHTTPError(status int, message string) *HTTPError {
+	return &HTTPError{Status: status, Message: message}
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("%d %s", e.Status, e.Message)
+}
+
+// Error of a malformed request body, see ResourceHandlerContext.Decode
+// Rendered as a 400 when returned by a handler, or with ResourceHandler.DecodeErrorStatus
+type DecodeError struct {
+	ContentType string
+	Err         error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("Could not decode the body as %s : %s", e.ContentType, e.Err.Error())
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// Alternate implementation, the server renders a returned error instead of the result
+// An *HTTPError is rendered with its status and message, a *DecodeError as a 400, any other error as a 500
+type ResourceHandlerImplementationWithError interface {
+	ResourceHandlerImplementation
+	ExecuteWithError(context *ResourceHandlerContext) (ResourceHandlerResult, error)
+}
+
+// Adapter to use a function returning an error as a resource handler implementation
+type ErrorHandlerFunc func(context *ResourceHandlerContext) (ResourceHandlerResult, error)
+
+func (f ErrorHandlerFunc) ExecuteWithError(context *ResourceHandlerContext) (ResourceHandlerResult, error) {
+	return f(context)
+}
+
+// Used when the implementation is called directly, e.g. from tests
+func (f ErrorHandlerFunc) Execute(context *ResourceHandlerContext) ResourceHandlerResult {
+
+	result, err := f(context)
+	if err != nil {
+		return errorResult(err)
+	}
+
+	return result
+}
+
+// Converts an error returned by a resource handler into a plain text result
+func errorResult(err error) ResourceHandlerResult {
+
+	// Also when wrapped, e.g. with fmt.Errorf and %w
+	var httpError *HTTPError
+	if errors.As(err, &httpError) {
+		return ResourceHandlerResult{HttpStatus: httpError.Status, Body: bytes.NewBufferString(httpError.Message), ContentType: `text/plain`}
+	}
+
+	var decodeError *DecodeError
+	if errors.As(err, &decodeError) {
+		return ResourceHandlerResult{HttpStatus: http.StatusBadRequest, Body: bytes.NewBufferString(decodeError.Error()), ContentType: `text/plain`}
+	}
+
+	// Returned while reading a streamed body, see ResourceHandler.StreamBody
+	if errors.Is(err, errRequestBodyTooLarge) || errors.Is(err, errDecompressedBodyTooLarge) {
+		return ResourceHandlerResult{HttpStatus: http.StatusRequestEntityTooLarge, Body: bytes.NewBufferString(err.Error()), ContentType: `text/plain`}
+	}
+
+	return ResourceHandlerResult{HttpStatus: http.StatusInternalServerError, Body: bytes.NewBufferString(http.StatusText(http.StatusInternalServerError)), ContentType: `text/plain`}
+}
+
+// Runs the implementation of the resource, through the endpoint middlewares and ExecuteWithError when available
+// With a shared key, the implementation is shared with identical concurrent requests, the middlewares still run for each
+// An idempotent request replays the response kept for its key instead, once the middlewares let it through,
+// and an automatic HEAD request may be answered from the Content-Length hint of the implementation
+func (s *Server) executeResourceHandler(endp *endpoint, method string, resource *ResourceHandler, context *ResourceHandlerContext, sharedKey string, requestId string) ResourceHandlerResult {
+
+	execute := func(context *ResourceHandlerContext) ResourceHandlerResult {
+		return s.executeImplementation(resource, context, requestId)
+	}
+
+	if sharedKey != `` {
+		execute = func(context *ResourceHandlerContext) ResourceHandlerResult {
+			return s.singleFlight.do(sharedKey, func() ResourceHandlerResult {
+				return s.executeImplementation(resource, context, requestId)
+			})
+		}
+	}
+
+	if context.contentLengthHintAllowed {
+		implementation := execute
+		execute = func(context *ResourceHandlerContext) ResourceHandlerResult {
+			if result, ok := contentLengthHintResult(resource, context, requestId); ok {
+				return result
+			}
+			return implementation(context)
+		}
+	}
+
+	if context.idempotency != nil {
+		implementation := execute
+		execute = func(context *ResourceHandlerContext) ResourceHandlerResult {
+			return s.replayOrExecute(context, implementation, requestId)
+		}
+	}
+
+	if len(endp.middlewares) == 0 {
+		return execute(context)
+	}
+
+	implementation := endp.wrapWithMiddlewares(method, ResourceHandlerFunc(execute))
+
+	return implementation.Execute(context)
+}
+
+func (s *Server) executeImplementation(resource *ResourceHandler, context *ResourceHandlerContext, requestId string) ResourceHandlerResult {
+
+	if context.DryRun {
+		return dryRunResult(resource, context)
+	}
+
+	implementation, ok := resource.Implementation.(ResourceHandlerImplementationWithError)
+	if !ok {
+		return resource.Implementation.Execute(context)
+	}
+
+	result, err := implementation.ExecuteWithError(context)
+	if err != nil {
+		Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Resource handler returned an error : %s", requestId, err.Error()))
+		result = errorResult(err)
+		var decodeError *DecodeError
+		if errors.As(err, &decodeError) && resource.DecodeErrorStatus != 0 {
+			result.HttpStatus = resource.DecodeErrorStatus
+		}
+	}
+
+	return result
+}
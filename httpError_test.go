@@ -0,0 +1,121 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Handler error tests : HTTPError and DecodeError mapping.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHandlerErrorStatuses(t *testing.T) {
+
+	tests := []struct {
+		name              string
+		decodeErrorStatus int
+		body              string
+		wrap              bool
+		status            int
+	}{
+		{`decoded`, 0, `{"a":1}`, false, http.StatusNoContent},
+		{`decode error`, 0, `{bad`, false, http.StatusBadRequest},
+		{`decode error mapped`, http.StatusUnprocessableEntity, `{bad`, false, http.StatusUnprocessableEntity},
+		{`wrapped decode error mapped`, http.StatusUnprocessableEntity, `{bad`, true, http.StatusUnprocessableEntity},
+		{`wrapped decode error`, 0, `{bad`, true, http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		s := NewServer(`/`, `:0`)
+		s.NewEndpoint(`/items`, ResourceHandler{Method: `POST`, ContentTypeIn: []string{`application/json`}, ContentTypeOut: []string{`text/plain`}, DecodeErrorStatus: test.decodeErrorStatus, Implementation: ErrorHandlerFunc(func(context *ResourceHandlerContext) (ResourceHandlerResult, error) {
+			var v map[string]int
+			if err := context.Decode(&v); err != nil {
+				if test.wrap {
+					err = fmt.Errorf(`Could not create the item : %w`, err)
+				}
+				return ResourceHandlerResult{}, err
+			}
+			return ResourceHandlerResult{HttpStatus: http.StatusNoContent}, nil
+		})})
+
+		recorder := serveTestRequest(s, `POST`, `/items`, strings.NewReader(test.body), map[string]string{`Content-Type`: `application/json`})
+		if recorder.Code != test.status {
+			t.Errorf(`%s : expected %d, got %d %q`, test.name, test.status, recorder.Code, recorder.Body.String())
+		}
+	}
+}
+
+func TestErrorResult(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{`http error`, NewHTTPError(http.StatusForbidden, `Forbidden`), http.StatusForbidden},
+		{`wrapped http error`, fmt.Errorf(`Could not load the item : %w`, NewHTTPError(http.StatusNotFound, `Not found`)), http.StatusNotFound},
+		{`decode error`, &DecodeError{ContentType: `application/json`, Err: errors.New(`unexpected end of JSON input`)}, http.StatusBadRequest},
+		{`other error`, errors.New(`database is down`), http.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		if result := errorResult(test.err); result.HttpStatus != test.status {
+			t.Errorf(`%s : expected %d, got %d`, test.name, test.status, result.HttpStatus)
+		}
+	}
+}
+
+func TestHandlerReturningHTTPError(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		body   string
+	}{
+		{`no error`, nil, http.StatusOK, `{"name":"bob"}`},
+		{`not found`, NewHTTPError(http.StatusNotFound, `No such user`), http.StatusNotFound, `No such user`},
+		{`conflict`, &HTTPError{Status: http.StatusConflict, Message: `User already exists`}, http.StatusConflict, `User already exists`},
+		{`wrapped`, fmt.Errorf(`Could not load the user : %w`, NewHTTPError(http.StatusForbidden, `Forbidden`)), http.StatusForbidden, `Forbidden`},
+		{`other error`, errors.New(`database is down`), http.StatusInternalServerError, `Internal Server Error`},
+	}
+
+	for _, test := range tests {
+		s := NewServer(`/`, `:0`)
+		s.NewEndpoint(`/users/bob`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`application/json`}, Implementation: ErrorHandlerFunc(func(context *ResourceHandlerContext) (ResourceHandlerResult, error) {
+			if test.err != nil {
+				return ResourceHandlerResult{}, test.err
+			}
+			return NewResult(http.StatusOK, map[string]string{`name`: `bob`}), nil
+		})})
+
+		recorder := serveTestRequest(s, `GET`, `/users/bob`, nil, nil)
+		if recorder.Code != test.status || strings.TrimSpace(recorder.Body.String()) != test.body {
+			t.Errorf(`%s : got %d %q, expected %d %q`, test.name, recorder.Code, recorder.Body.String(), test.status, test.body)
+		}
+	}
+}
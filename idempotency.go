@@ -0,0 +1,199 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Idempotency-Key support : replays the first response of a POST/PATCH for duplicate keys.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	const_idempotency_key_header = `Idempotency-Key`
+)
+
+// A response kept by an IdempotencyStore, replayed for duplicate keys
+type IdempotentResponse struct {
+	HttpStatus  int
+	Body        []byte
+	ContentType string
+	Header      http.Header // headers of the result, e.g. Location or Set-Cookie
+}
+
+// Pluggable storage for idempotent responses, implementations must be safe for concurrent use
+type IdempotencyStore interface {
+	Get(key string) (*IdempotentResponse, bool)
+	Set(key string, response *IdempotentResponse, ttl time.Duration)
+}
+
+type memoryIdempotencyEntry struct {
+	response  *IdempotentResponse
+	expiresAt time.Time
+}
+
+// In memory IdempotencyStore, expired entries are dropped when looked up and swept from time to time as new ones are set
+type MemoryIdempotencyStore struct {
+	mutex     sync.Mutex
+	entries   map[string]memoryIdempotencyEntry
+	nextSweep time.Time
+}
+
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{entries: make(map[string]memoryIdempotencyEntry)}
+}
+
+func (m *MemoryIdempotencyStore) Get(key string) (*IdempotentResponse, bool) {
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	entry, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if time.Now().After(entry.expiresAt) {
+		delete(m.entries, key)
+		return nil, false
+	}
+
+	return entry.response, true
+}
+
+func (m *MemoryIdempotencyStore) Set(key string, response *IdempotentResponse, ttl time.Duration) {
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := time.Now()
+
+	if now.After(m.nextSweep) {
+		for k, entry := range m.entries {
+			if now.After(entry.expiresAt) {
+				delete(m.entries, k)
+			}
+		}
+		m.nextSweep = now.Add(ttl)
+	}
+
+	m.entries[key] = memoryIdempotencyEntry{response: response, expiresAt: now.Add(ttl)}
+}
+
+// Keys of the requests being executed, so that a duplicate sent meanwhile is not executed too
+// Held by this process only, a store shared by several servers does not prevent concurrent duplicates across them
+type idempotencyReservations struct {
+	mutex sync.Mutex
+	keys  map[string]bool
+}
+
+func newIdempotencyReservations() *idempotencyReservations {
+	return &idempotencyReservations{keys: make(map[string]bool)}
+}
+
+// Returns false if the key is already reserved
+func (r *idempotencyReservations) reserve(key string) bool {
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.keys[key] {
+		return false
+	}
+	r.keys[key] = true
+
+	return true
+}
+
+func (r *idempotencyReservations) release(key string) {
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.keys, key)
+}
+
+func isIdempotencyMethod(method string) bool {
+	return method == `POST` || method == `PATCH`
+}
+
+// Idempotency of a request, followed through the middlewares
+type idempotentRequest struct {
+	key      string
+	reserved bool // the key is held by this request, which keeps its response
+	replayed bool // the response kept for the key was replayed
+}
+
+// Duplicate requests share a key : same method, URL, Idempotency-Key header and credentials ( Authorization and Cookie headers ),
+// so that a response is never replayed to another caller
+func idempotencyKey(request *http.Request) string {
+	return strings.Join([]string{request.Method, request.Host + request.URL.RequestURI(), request.Header.Get(const_idempotency_key_header), request.Header.Get(`Authorization`), request.Header.Get(`Cookie`)}, "\n")
+}
+
+// Replays the response kept for the key of the request, or else executes it
+// Reserved before the lookup, so that the response of a request finishing meanwhile is found
+func (s *Server) replayOrExecute(context *ResourceHandlerContext, execute func(*ResourceHandlerContext) ResourceHandlerResult, requestId string) ResourceHandlerResult {
+
+	idempotency := context.idempotency
+
+	if !s.idempotencyReservations.reserve(idempotency.key) {
+		message := fmt.Sprintf("A request with the same idempotency key is in progress")
+		Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s A request with idempotency key %s is in progress", requestId, context.Header.Get(const_idempotency_key_header)))
+		return ResourceHandlerResult{HttpStatus: http.StatusConflict, Body: bytes.NewBufferString(message), ContentType: `text/plain`}
+	}
+	idempotency.reserved = true
+
+	if cached, ok := s.idempotencyStore.Get(idempotency.key); ok {
+		Flog(FLOG_TYPE_INFO, fmt.Sprintf("%s Replaying response for idempotency key %s", requestId, context.Header.Get(const_idempotency_key_header)))
+		idempotency.replayed = true
+		return ResourceHandlerResult{HttpStatus: cached.HttpStatus, Body: bytes.NewBuffer(cached.Body), Header: cached.Header.Clone(), ContentType: cached.ContentType}
+	}
+
+	return execute(context)
+}
+
+// Keeps the response of the request holding the key, server errors excepted so that the request can be retried
+func (s *Server) keepIdempotentResponse(idempotency *idempotentRequest, result *ResourceHandlerResult, contentType string) {
+
+	if !idempotency.reserved || idempotency.replayed || result.HttpStatus >= 500 || result.Stream != nil {
+		return
+	}
+
+	cached := &IdempotentResponse{HttpStatus: result.HttpStatus, ContentType: contentType, Header: result.Header.Clone()}
+	if result.Body != nil {
+		cached.Body = append([]byte{}, result.Body.Bytes()...)
+	}
+
+	s.idempotencyStore.Set(idempotency.key, cached, s.idempotencyTtl)
+}
+
+func (s *Server) releaseIdempotencyKey(idempotency *idempotentRequest) {
+	if idempotency.reserved {
+		s.idempotencyReservations.release(idempotency.key)
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Idempotency-Key tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Server whose POST /orders handler counts its runs, waiting for release if not nil
+func newIdempotencyTestServer(runs *int32, release chan struct{}) *Server {
+
+	s := NewServer(`/`, `:0`)
+	s.EnableIdempotency(time.Minute, nil)
+	s.NewEndpoint(`/orders`, ResourceHandler{Method: `POST`, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+		run := atomic.AddInt32(runs, 1)
+		if release != nil {
+			<-release
+		}
+		result := ResourceHandlerResult{HttpStatus: http.StatusCreated, Body: bytes.NewBufferString(fmt.Sprintf(`order %d`, run))}
+		result = result.withHeader(`Location`, fmt.Sprintf(`/orders/%d`, run))
+		return result.WithCookie(&http.Cookie{Name: `last_order`, Value: fmt.Sprint(run)})
+	})})
+
+	return s
+}
+
+func TestIdempotencyReplay(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		first      string
+		second     string
+		firstKey   string
+		secondKey  string
+		replayed   bool
+		secondBody string
+	}{
+		{`same key`, `/orders`, `/orders`, `abc`, `abc`, true, `order 1`},
+		{`other key`, `/orders`, `/orders`, `abc`, `def`, false, `order 2`},
+		{`other query string`, `/orders?account=1`, `/orders?account=2`, `abc`, `abc`, false, `order 2`},
+	}
+
+	for _, test := range tests {
+		var runs int32
+		s := newIdempotencyTestServer(&runs, nil)
+
+		first := serveTestRequest(s, `POST`, test.first, nil, map[string]string{`Idempotency-Key`: test.firstKey})
+		second := serveTestRequest(s, `POST`, test.second, nil, map[string]string{`Idempotency-Key`: test.secondKey})
+
+		if first.Code != http.StatusCreated || second.Code != http.StatusCreated {
+			t.Errorf(`%s : got %d and %d`, test.name, first.Code, second.Code)
+		}
+		if second.Body.String() != test.secondBody {
+			t.Errorf(`%s : second body %q, expected %q`, test.name, second.Body.String(), test.secondBody)
+		}
+		if replayed := runs == 1; replayed != test.replayed {
+			t.Errorf(`%s : replayed %t, expected %t`, test.name, replayed, test.replayed)
+		}
+		for _, name := range []string{`Location`, `Set-Cookie`} {
+			if test.replayed && second.Header().Get(name) != first.Header().Get(name) {
+				t.Errorf(`%s : replayed %s %q, expected %q`, test.name, name, second.Header().Get(name), first.Header().Get(name))
+			}
+		}
+	}
+}
+
+func TestIdempotencyConcurrentDuplicate(t *testing.T) {
+
+	var runs int32
+	release := make(chan struct{})
+	s := newIdempotencyTestServer(&runs, release)
+
+	first := make(chan *httptest.ResponseRecorder)
+	go func() {
+		first <- serveTestRequest(s, `POST`, `/orders`, nil, map[string]string{`Idempotency-Key`: `abc`})
+	}()
+	for atomic.LoadInt32(&runs) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	duplicate := serveTestRequest(s, `POST`, `/orders`, nil, map[string]string{`Idempotency-Key`: `abc`})
+	close(release)
+
+	if duplicate.Code != http.StatusConflict {
+		t.Errorf(`duplicate : expected 409, got %d`, duplicate.Code)
+	}
+	if recorder := <-first; recorder.Code != http.StatusCreated {
+		t.Errorf(`first : expected 201, got %d`, recorder.Code)
+	}
+
+	// Once the first request is done, its response is replayed
+	if replay := serveTestRequest(s, `POST`, `/orders`, nil, map[string]string{`Idempotency-Key`: `abc`}); replay.Code != http.StatusCreated || replay.Body.String() != `order 1` {
+		t.Errorf(`replay : got %d %q`, replay.Code, replay.Body.String())
+	}
+	if runs != 1 {
+		t.Errorf(`handler ran %d times, expected once`, runs)
+	}
+}
+
+func TestMemoryIdempotencyStoreSweepsExpiredKeys(t *testing.T) {
+
+	store := NewMemoryIdempotencyStore()
+	store.Set(`old`, &IdempotentResponse{HttpStatus: http.StatusOK}, 10*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	store.Set(`new`, &IdempotentResponse{HttpStatus: http.StatusOK}, 10*time.Millisecond)
+
+	if _, ok := store.entries[`old`]; ok {
+		t.Error(`expired key was not swept`)
+	}
+	if _, ok := store.Get(`new`); !ok {
+		t.Error(`new key is missing`)
+	}
+}
+
+func TestIdempotencyReplayIsScopedToTheCaller(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		authorization string
+		cookie        string
+		status        int
+		body          string
+		runs          int32
+	}{
+		{`same caller`, `Bearer alice`, `session=1`, http.StatusCreated, `secret order for Bearer alice`, 1},
+		{`anonymous caller`, ``, ``, http.StatusUnauthorized, `Unauthorized`, 1},
+		{`other caller`, `Bearer bob`, `session=1`, http.StatusCreated, `secret order for Bearer bob`, 2},
+		{`same caller with another cookie`, `Bearer alice`, `session=2`, http.StatusCreated, `secret order for Bearer alice`, 2},
+	}
+
+	for _, test := range tests {
+		var runs int32
+		s := NewServer(`/`, `:0`)
+		s.EnableIdempotency(time.Minute, nil)
+		s.NewEndpoint(`/orders`, ResourceHandler{Method: `POST`, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			atomic.AddInt32(&runs, 1)
+			return ResourceHandlerResult{HttpStatus: http.StatusCreated, Body: bytes.NewBufferString(`secret order for ` + context.Header.Get(`Authorization`))}
+		})})
+		s.UseOnEndpoint(`/orders`, func(next ResourceHandlerImplementation) ResourceHandlerImplementation {
+			return ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+				if context.Header.Get(`Authorization`) == `` {
+					return ResourceHandlerResult{HttpStatus: http.StatusUnauthorized, Body: bytes.NewBufferString(`Unauthorized`)}
+				}
+				return next.Execute(context)
+			})
+		})
+
+		serveTestRequest(s, `POST`, `/orders`, nil, map[string]string{`Idempotency-Key`: `abc`, `Authorization`: `Bearer alice`, `Cookie`: `session=1`})
+		recorder := serveTestRequest(s, `POST`, `/orders`, nil, map[string]string{`Idempotency-Key`: `abc`, `Authorization`: test.authorization, `Cookie`: test.cookie})
+
+		if recorder.Code != test.status || recorder.Body.String() != test.body {
+			t.Errorf(`%s : got %d %q, expected %d %q`, test.name, recorder.Code, recorder.Body.String(), test.status, test.body)
+		}
+		if runs != test.runs {
+			t.Errorf(`%s : handler ran %d times, expected %d`, test.name, runs, test.runs)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Client IP resolution behind trusted proxies and IP allowlisting.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// Parses CIDR ranges, a single IP being accepted as the range holding only itself
+func parseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+
+	networks := []*net.IPNet{}
+
+	for _, cidr := range cidrs {
+
+		cidr = strings.TrimSpace(cidr)
+
+		if !strings.Contains(cidr, `/`) {
+			ip := net.ParseIP(cidr)
+			if ip == nil {
+				return nil, errors.New(fmt.Sprintf(`Invalid IP or CIDR range '%s'`, cidr))
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf(`Invalid IP or CIDR range '%s'`, cidr))
+		}
+		networks = append(networks, network)
+	}
+
+	return networks, nil
+}
+
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Requests coming through these proxies are attributed to the client named in X-Forwarded-For
+func (s *Server) SetTrustedProxies(cidrs []string) error {
+
+	networks, err := parseCIDRs(cidrs)
+	if err != nil {
+		return err
+	}
+
+	s.trustedProxies = networks
+
+	return nil
+}
+
+// Only requests from a client IP in one of the ranges are served, others get a 403 before routing
+// An empty list disables the allowlist
+func (s *Server) SetIPAllowlist(cidrs []string) error {
+
+	networks, err := parseCIDRs(cidrs)
+	if err != nil {
+		return err
+	}
+
+	if len(networks) == 0 {
+		networks = nil
+	}
+
+	s.ipAllowlist = networks
+
+	return nil
+}
+
+// IP of the client : the connection peer, or while it is a trusted proxy, the previous hop in X-Forwarded-For
+// Nil if it can not be determined
+func (s *Server) clientIP(request *http.Request) net.IP {
+
+	host, _, err := net.SplitHostPort(request.RemoteAddr)
+	if err != nil {
+		host = request.RemoteAddr
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || len(s.trustedProxies) == 0 {
+		return ip
+	}
+
+	// Rightmost first : only the hops added by trusted proxies can be believed
+	forwardedFor := strings.Split(strings.Join(request.Header.Values(`X-Forwarded-For`), `,`), `,`)
+	for i := len(forwardedFor) - 1; i >= 0 && containsIP(s.trustedProxies, ip); i-- {
+		forwardedIP := net.ParseIP(strings.TrimSpace(forwardedFor[i]))
+		if forwardedIP == nil {
+			break
+		}
+		ip = forwardedIP
+	}
+
+	return ip
+}
+
+// Returns false after answering 403 if the client IP is not allowed
+func (s *Server) checkIPAllowlist(writer http.ResponseWriter, request *http.Request, requestId string) bool {
+
+	if s.ipAllowlist == nil {
+		return true
+	}
+
+	ip := s.clientIP(request)
+	if ip != nil && containsIP(s.ipAllowlist, ip) {
+		return true
+	}
+
+	message := fmt.Sprintf("Access denied")
+	Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Access denied to client IP %s", requestId, ip))
+	s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusForbidden, Body: bytes.NewBufferString(message)}, `text/plain`, requestId)
+
+	return false
+}
@@ -0,0 +1,147 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      IP allowlist and trusted proxies tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Serves GET /admin as coming from remoteAddr, through proxies if forwardedFor is given
+func serveIPTestRequest(s *Server, remoteAddr string, forwardedFor string) *httptest.ResponseRecorder {
+
+	request := httptest.NewRequest(`GET`, `/admin`, nil)
+	request.RemoteAddr = remoteAddr
+	request.Header.Set(`Accept`, `*/*`)
+	if forwardedFor != `` {
+		request.Header.Set(`X-Forwarded-For`, forwardedFor)
+	}
+
+	recorder := httptest.NewRecorder()
+	s.ServeHTTP(recorder, request)
+
+	return recorder
+}
+
+func TestIPAllowlist(t *testing.T) {
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor string
+		status       int
+	}{
+		{`allowed range`, `10.1.2.3:4321`, ``, http.StatusOK},
+		{`allowed single ip`, `192.168.1.7:4321`, ``, http.StatusOK},
+		{`allowed ipv6 range`, `[fd00::1]:4321`, ``, http.StatusOK},
+		{`blocked`, `203.0.113.9:4321`, ``, http.StatusForbidden},
+		{`blocked neighbour of single ip`, `192.168.1.8:4321`, ``, http.StatusForbidden},
+		{`allowed through a trusted proxy`, `172.16.0.1:4321`, `10.1.2.3`, http.StatusOK},
+		{`blocked through a trusted proxy`, `172.16.0.1:4321`, `203.0.113.9`, http.StatusForbidden},
+		{`spoofed hop before a trusted proxy`, `172.16.0.1:4321`, `10.1.2.3, 203.0.113.9`, http.StatusForbidden},
+		{`forwarded by an untrusted peer`, `203.0.113.9:4321`, `10.1.2.3`, http.StatusForbidden},
+	}
+
+	s := NewServer(`/`, `:0`)
+	if err := s.SetIPAllowlist([]string{`10.0.0.0/8`, `192.168.1.7`, `fd00::/8`}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetTrustedProxies([]string{`172.16.0.0/12`}); err != nil {
+		t.Fatal(err)
+	}
+	s.NewEndpoint(`/admin`, textResourceHandler(`GET`, `admin`))
+
+	for _, test := range tests {
+		if recorder := serveIPTestRequest(s, test.remoteAddr, test.forwardedFor); recorder.Code != test.status {
+			t.Errorf(`%s : expected %d, got %d`, test.name, test.status, recorder.Code)
+		}
+	}
+
+	// An empty list disables the allowlist
+	s.SetIPAllowlist(nil)
+	if recorder := serveIPTestRequest(s, `203.0.113.9:4321`, ``); recorder.Code != http.StatusOK {
+		t.Errorf(`disabled allowlist : expected 200, got %d`, recorder.Code)
+	}
+}
+
+func TestInvalidCIDRs(t *testing.T) {
+
+	tests := []struct {
+		cidrs []string
+		err   string
+	}{
+		{[]string{`10.0.0.0/8`, `not an ip`}, `Invalid IP or CIDR range 'not an ip'`},
+		{[]string{`10.0.0.0/33`}, `Invalid IP or CIDR range '10.0.0.0/33'`},
+		{[]string{`300.1.2.3`}, `Invalid IP or CIDR range '300.1.2.3'`},
+	}
+
+	for _, test := range tests {
+		s := NewServer(`/`, `:0`)
+		if err := s.SetIPAllowlist(test.cidrs); err == nil || err.Error() != test.err {
+			t.Errorf(`%v : expected %q, got %v`, test.cidrs, test.err, err)
+		}
+		if err := s.SetTrustedProxies(test.cidrs); err == nil || err.Error() != test.err {
+			t.Errorf(`%v : expected %q from the trusted proxies, got %v`, test.cidrs, test.err, err)
+		}
+	}
+}
+
+func TestIPAllowlistGuardsHealthChecks(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		draining   bool
+		status     int
+		body       string
+	}{
+		{`allowed`, `10.1.2.3:4321`, false, http.StatusOK, `OK`},
+		{`allowed while draining`, `10.1.2.3:4321`, true, http.StatusServiceUnavailable, `Draining`},
+		{`blocked`, `203.0.113.9:4321`, false, http.StatusForbidden, ``},
+		{`blocked while draining`, `203.0.113.9:4321`, true, http.StatusForbidden, ``},
+	}
+
+	for _, test := range tests {
+		s := NewServer(`/`, `:0`)
+		s.EnableHealthEndpoint(`/health`)
+		if err := s.SetIPAllowlist([]string{`10.0.0.0/8`}); err != nil {
+			t.Fatal(err)
+		}
+		if test.draining {
+			s.BeginDrain()
+		}
+
+		request := httptest.NewRequest(`GET`, `/health`, nil)
+		request.RemoteAddr = test.remoteAddr
+		recorder := httptest.NewRecorder()
+		s.ServeHTTP(recorder, request)
+
+		if recorder.Code != test.status || (test.body != `` && recorder.Body.String() != test.body) {
+			t.Errorf(`%s : got %d %q, expected %d %q`, test.name, recorder.Code, recorder.Body.String(), test.status, test.body)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Streams a JSON array element by element, without marshaling the whole array.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+)
+
+// Writes the elements of a JSON array to the response as they are pushed
+type JSONArrayWriter struct {
+	stream  *ResponseStream
+	encoder *json.Encoder
+	count   int
+}
+
+// Encodes one element of the array
+func (a *JSONArrayWriter) Push(v interface{}) error {
+
+	separator := `,`
+	if a.count == 0 {
+		separator = `[`
+	}
+
+	_, err := a.stream.Write([]byte(separator))
+	if err != nil {
+		return err
+	}
+
+	a.count++
+
+	return a.encoder.Encode(v)
+}
+
+// Sends the elements pushed so far to the client
+func (a *JSONArrayWriter) Flush() {
+	a.stream.Flush()
+}
+
+func (a *JSONArrayWriter) close() error {
+
+	end := `]`
+	if a.count == 0 {
+		end = `[]`
+	}
+
+	_, err := a.stream.Write([]byte(end))
+
+	return err
+}
+
+// True for application/json and +json media types
+func isJSONContentType(contentType string) bool {
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == `application/json` || strings.HasSuffix(mediaType, `+json`)
+}
+
+// Result streaming the elements produce pushes as a JSON array, e.g. rows read from a database cursor
+// Returns an error if the negotiated response content type is not JSON, the handler then answers otherwise
+// If produce fails the array is left unterminated, so that clients do not mistake a partial array for a whole one
+func (c *ResourceHandlerContext) StreamJSONArray(status int, produce func(array *JSONArrayWriter) error) (ResourceHandlerResult, error) {
+
+	if !isJSONContentType(c.ResponseContentType()) {
+		return ResourceHandlerResult{}, errors.New(fmt.Sprintf(`Can not stream a JSON array as %s`, c.ResponseContentType()))
+	}
+
+	result := ResourceHandlerResult{HttpStatus: status}
+	result.Stream = func(stream *ResponseStream) error {
+
+		array := &JSONArrayWriter{stream: stream, encoder: json.NewEncoder(stream)}
+
+		err := produce(array)
+		if err != nil {
+			return err
+		}
+
+		return array.close()
+	}
+
+	return result, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      JSON array streaming tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+type jsonStreamTestRow struct {
+	Id int `json:"id"`
+}
+
+func TestStreamJSONArray(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		accept string
+		count  int
+		fail   bool
+		status int
+		valid  bool
+	}{
+		{`many elements`, `application/json`, 1000, false, http.StatusOK, true},
+		{`empty array`, `application/json`, 0, false, http.StatusOK, true},
+		{`+json media type`, `application/vnd.rows+json`, 10, false, http.StatusOK, true},
+		{`not json`, `text/plain`, 10, false, http.StatusOK, false},
+		{`failing producer`, `application/json`, 10, true, http.StatusOK, false},
+	}
+
+	for _, test := range tests {
+		s := NewServer(`/`, `:0`)
+		s.NewEndpoint(`/rows`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`application/json`, `application/vnd.rows+json`, `text/plain`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			result, err := context.StreamJSONArray(http.StatusOK, func(array *JSONArrayWriter) error {
+				for i := 0; i < test.count; i++ {
+					if err := array.Push(jsonStreamTestRow{Id: i}); err != nil {
+						return err
+					}
+					if i%100 == 0 {
+						array.Flush()
+					}
+				}
+				if test.fail {
+					return errors.New(`cursor closed`)
+				}
+				return nil
+			})
+			if err != nil {
+				return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(`rows as text`)}
+			}
+			return result
+		})})
+
+		recorder := serveTestRequest(s, `GET`, `/rows`, nil, map[string]string{`Accept`: test.accept})
+
+		var rows []jsonStreamTestRow
+		err := json.Unmarshal(recorder.Body.Bytes(), &rows)
+		if recorder.Code != test.status || (err == nil) != test.valid {
+			t.Errorf(`%s : got %d with a valid array %t, expected %d and %t`, test.name, recorder.Code, err == nil, test.status, test.valid)
+			continue
+		}
+		if test.valid && (len(rows) != test.count || (test.count > 0 && rows[test.count-1].Id != test.count-1)) {
+			t.Errorf(`%s : got %d rows, expected %d`, test.name, len(rows), test.count)
+		}
+	}
+}
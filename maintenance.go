@@ -0,0 +1,98 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Maintenance mode and health endpoint.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// Answers 200 on url, including while in maintenance mode, and 503 once draining, e.g. for load balancer health checks
+// Subject to the IP allowlist, see SetIPAllowlist
+func (s *Server) EnableHealthEndpoint(url string) {
+
+	Flog(FLOG_TYPE_ACTION, fmt.Sprintf("Enabling health endpoint on %s\n", TermColorEscape(url, TERM_COLOR_BLUE)))
+
+	s.healthEndpointEnabled = true
+	s.healthEndpointUrl = url
+}
+
+// Answers 503 to every request but the health endpoint, with a Retry-After header if retryAfter is not 0
+// Can be toggled while serving, e.g. during deploys
+func (s *Server) SetMaintenanceMode(on bool, retryAfter time.Duration) {
+
+	atomic.StoreInt64(&s.maintenanceRetryAfter, int64(retryAfter))
+
+	if on {
+		atomic.StoreInt32(&s.maintenanceMode, 1)
+		Flog(FLOG_TYPE_ACTION, "Entering maintenance mode\n")
+	} else {
+		atomic.StoreInt32(&s.maintenanceMode, 0)
+		Flog(FLOG_TYPE_ACTION, "Leaving maintenance mode\n")
+	}
+}
+
+func (s *Server) isInMaintenance() bool {
+	return atomic.LoadInt32(&s.maintenanceMode) == 1
+}
+
+// Makes the health endpoint answer 503 while requests keep being served, so that load balancers stop routing
+// to the server before it is shut down, e.g. during rolling deploys
+func (s *Server) BeginDrain() {
+	atomic.StoreInt32(&s.draining, 1)
+	Flog(FLOG_TYPE_ACTION, "Draining, the health endpoint now fails\n")
+}
+
+func (s *Server) isDraining() bool {
+	return atomic.LoadInt32(&s.draining) == 1
+}
+
+func (s *Server) serveHealth(writer http.ResponseWriter, requestId string) {
+
+	if s.isDraining() {
+		s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusServiceUnavailable, Body: bytes.NewBufferString(`Draining`)}, `text/plain`, requestId)
+		return
+	}
+
+	s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(`OK`)}, `text/plain`, requestId)
+}
+
+func (s *Server) serveMaintenance(writer http.ResponseWriter, requestId string) {
+
+	message := fmt.Sprintf("Server is in maintenance")
+	Flog(FLOG_TYPE_WARNING, fmt.Sprintf("%s Server is in maintenance", requestId))
+
+	result := ResourceHandlerResult{HttpStatus: http.StatusServiceUnavailable, Body: bytes.NewBufferString(message)}
+	retryAfter := time.Duration(atomic.LoadInt64(&s.maintenanceRetryAfter))
+	if retryAfter > 0 {
+		result = result.WithRetryAfter(retryAfter)
+	}
+
+	s.renderResourceResult(writer, &result, `text/plain`, requestId)
+}
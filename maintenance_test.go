@@ -0,0 +1,94 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Maintenance mode and health endpoint tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestMaintenanceMode(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		maintenance bool
+		retryAfter  time.Duration
+		path        string
+		status      int
+		header      string
+	}{
+		{`serving`, false, 0, `/users`, http.StatusOK, ``},
+		{`serving health`, false, 0, `/health`, http.StatusOK, ``},
+		{`maintenance`, true, 2 * time.Minute, `/users`, http.StatusServiceUnavailable, `120`},
+		{`maintenance without retry after`, true, 0, `/users`, http.StatusServiceUnavailable, ``},
+		{`maintenance health`, true, 2 * time.Minute, `/health`, http.StatusOK, ``},
+		{`back from maintenance`, false, 0, `/users`, http.StatusOK, ``},
+	}
+
+	s := NewServer(`/`, `:0`)
+	s.EnableHealthEndpoint(`/health`)
+	s.NewEndpoint(`/users`, textResourceHandler(`GET`, `users`))
+
+	// Toggled on the same server, as during a deploy
+	for _, test := range tests {
+		s.SetMaintenanceMode(test.maintenance, test.retryAfter)
+
+		recorder := serveTestRequest(s, `GET`, test.path, nil, nil)
+		if recorder.Code != test.status || recorder.Header().Get(`Retry-After`) != test.header {
+			t.Errorf(`%s : got %d with Retry-After %q, expected %d with %q`, test.name, recorder.Code, recorder.Header().Get(`Retry-After`), test.status, test.header)
+		}
+	}
+}
+
+func TestBeginDrain(t *testing.T) {
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{`/health`, http.StatusServiceUnavailable, `Draining`},
+		{`/users`, http.StatusOK, `users`},
+	}
+
+	s := NewServer(`/`, `:0`)
+	s.EnableHealthEndpoint(`/health`)
+	s.NewEndpoint(`/users`, textResourceHandler(`GET`, `users`))
+
+	if recorder := serveTestRequest(s, `GET`, `/health`, nil, nil); recorder.Code != http.StatusOK || recorder.Body.String() != `OK` {
+		t.Errorf(`before draining : got %d %q`, recorder.Code, recorder.Body.String())
+	}
+
+	s.BeginDrain()
+
+	for _, test := range tests {
+		recorder := serveTestRequest(s, `GET`, test.path, nil, nil)
+		if recorder.Code != test.status || recorder.Body.String() != test.body {
+			t.Errorf(`%s : got %d %q, expected %d %q`, test.path, recorder.Code, recorder.Body.String(), test.status, test.body)
+		}
+	}
+}
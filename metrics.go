@@ -0,0 +1,95 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Per request metrics : a structured entry given to an observer once the response is written.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"io"
+	"net/http"
+	"time"
+)
+
+// Describes a served request, given to the metrics observer
+type RequestLogEntry struct {
+	RequestId     string
+	Method        string
+	Path          string
+	Route         string // route template of the matched endpoint, empty if none matched
+	HttpStatus    int
+	Duration      time.Duration
+	RequestBytes  int64 // request body bytes read from the client, compressed if the body was
+	ResponseBytes int64 // response body bytes written to the client, after any compression
+}
+
+// Registers a function called with the log entry of every served request
+func (s *Server) SetMetricsObserver(observer func(entry *RequestLogEntry)) {
+	s.metricsObserver = observer
+}
+
+// Counts the request body bytes read through it
+type countingReadCloser struct {
+	io.ReadCloser
+	bytesRead int64
+}
+
+func (c *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.bytesRead += int64(n)
+	return n, err
+}
+
+// Records the status and the number of body bytes written through it
+type responseWriterRecorder struct {
+	http.ResponseWriter
+	httpStatus   int
+	bytesWritten int64
+}
+
+func newResponseWriterRecorder(writer http.ResponseWriter) *responseWriterRecorder {
+	return &responseWriterRecorder{ResponseWriter: writer, httpStatus: http.StatusOK}
+}
+
+func (r *responseWriterRecorder) WriteHeader(httpStatus int) {
+	r.httpStatus = httpStatus
+	r.ResponseWriter.WriteHeader(httpStatus)
+}
+
+func (r *responseWriterRecorder) Write(p []byte) (int, error) {
+	n, err := r.ResponseWriter.Write(p)
+	r.bytesWritten += int64(n)
+	return n, err
+}
+
+// Keeps streaming responses working through the recorder
+func (r *responseWriterRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Gives http.ResponseController access to the connection, e.g. for write deadlines
+func (r *responseWriterRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
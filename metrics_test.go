@@ -0,0 +1,156 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Metrics observer tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestMetricsObserver(t *testing.T) {
+
+	tests := []struct {
+		name         string
+		method       string
+		target       string
+		body         string
+		route        string
+		status       int
+		requestBytes int64
+	}{
+		{`matched`, `GET`, `/users/42`, ``, `/users/{id:digits}`, http.StatusOK, 0},
+		{`with a body`, `POST`, `/users`, `{"name":"bob"}`, `/users`, http.StatusCreated, 14},
+		{`rejected route variable`, `GET`, `/users/abc`, ``, ``, http.StatusBadRequest, 0},
+		{`not found`, `GET`, `/unknown`, ``, ``, http.StatusNotFound, 0},
+	}
+
+	for _, test := range tests {
+		var entries []*RequestLogEntry
+
+		create := textResourceHandler(`POST`, `created`)
+		create.ContentTypeIn = []string{`application/json`}
+		create.Implementation = ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			return ResourceHandlerResult{HttpStatus: http.StatusCreated, Body: bytes.NewBufferString(`created`)}
+		})
+
+		s := NewServer(`/`, `:0`)
+		s.SetMetricsObserver(func(entry *RequestLogEntry) {
+			entries = append(entries, entry)
+		})
+		s.NewRouteVariableType(`digits`, digitsRouteVariableType{})
+		s.NewEndpoint(`/users/{id:digits}`, textResourceHandler(`GET`, `user`))
+		s.NewEndpoint(`/users`, create)
+
+		header := map[string]string{}
+		if test.body != `` {
+			header[`Content-Type`] = `application/json`
+		}
+		recorder := serveTestRequest(s, test.method, test.target, strings.NewReader(test.body), header)
+
+		if len(entries) != 1 {
+			t.Errorf(`%s : observed %d entries`, test.name, len(entries))
+			continue
+		}
+		entry := entries[0]
+		if entry.RequestId == `` || entry.Method != test.method || entry.Path != test.target || entry.Route != test.route || entry.HttpStatus != test.status {
+			t.Errorf(`%s : entry %+v`, test.name, entry)
+		}
+		if entry.RequestBytes != test.requestBytes || entry.ResponseBytes != int64(recorder.Body.Len()) {
+			t.Errorf(`%s : %d bytes read and %d written, expected %d and %d`, test.name, entry.RequestBytes, entry.ResponseBytes, test.requestBytes, recorder.Body.Len())
+		}
+		if entry.Duration <= 0 {
+			t.Errorf(`%s : duration %s`, test.name, entry.Duration)
+		}
+	}
+}
+
+func TestMetricsCountWireBytes(t *testing.T) {
+
+	payload := strings.Repeat(`hello `, 1000)
+
+	tests := []struct {
+		name           string
+		gzipRequest    bool
+		acceptEncoding string
+		stream         bool
+	}{
+		{`plain`, false, ``, false},
+		{`compressed request`, true, ``, false},
+		{`compressed response`, false, `gzip`, false},
+		{`compressed stream`, false, `gzip`, true},
+		{`stream`, false, ``, true},
+	}
+
+	for _, test := range tests {
+		var entry *RequestLogEntry
+
+		s := NewServer(`/`, `:0`)
+		s.EnableRequestDecompression(0)
+		s.EnableResponseCompression(true)
+		s.SetMetricsObserver(func(e *RequestLogEntry) {
+			entry = e
+		})
+		s.NewEndpoint(`/echo`, ResourceHandler{Method: `POST`, ContentTypeIn: []string{`text/plain`}, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			body := context.Body.String()
+			if test.stream {
+				return ResourceHandlerResult{HttpStatus: http.StatusOK, Stream: func(stream *ResponseStream) error {
+					_, err := io.WriteString(stream, body)
+					return err
+				}}
+			}
+			return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(body)}
+		})})
+
+		body := new(bytes.Buffer)
+		header := map[string]string{`Content-Type`: `text/plain`, `Accept-Encoding`: test.acceptEncoding}
+		if test.gzipRequest {
+			compressor := gzip.NewWriter(body)
+			io.WriteString(compressor, payload)
+			compressor.Close()
+			header[`Content-Encoding`] = `gzip`
+		} else {
+			body.WriteString(payload)
+		}
+		sent := int64(body.Len())
+
+		recorder := serveTestRequest(s, `POST`, `/echo`, body, header)
+
+		if recorder.Code != http.StatusOK || entry == nil {
+			t.Errorf(`%s : got %d`, test.name, recorder.Code)
+			continue
+		}
+		if entry.RequestBytes != sent || entry.ResponseBytes != int64(recorder.Body.Len()) {
+			t.Errorf(`%s : %d bytes read and %d written, expected %d and %d`, test.name, entry.RequestBytes, entry.ResponseBytes, sent, recorder.Body.Len())
+		}
+		if compressed := entry.ResponseBytes < int64(len(payload)); compressed != (test.acceptEncoding == `gzip`) {
+			t.Errorf(`%s : %d bytes written for a payload of %d`, test.name, entry.ResponseBytes, len(payload))
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Endpoint middlewares, optionally scoped to some HTTP methods.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Wraps the implementation of a resource handler, e.g. to check credentials before calling next
+type Middleware func(next ResourceHandlerImplementation) ResourceHandlerImplementation
+
+type scopedMiddleware struct {
+	middleware Middleware
+	methods    []string // empty : every method
+}
+
+func (m *scopedMiddleware) appliesTo(method string) bool {
+
+	if len(m.methods) == 0 {
+		return true
+	}
+
+	for _, scopedMethod := range m.methods {
+		if strings.EqualFold(scopedMethod, method) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Adapter to use a function as a resource handler implementation, e.g. in a Middleware
+type ResourceHandlerFunc func(context *ResourceHandlerContext) ResourceHandlerResult
+
+func (f ResourceHandlerFunc) Execute(context *ResourceHandlerContext) ResourceHandlerResult {
+	return f(context)
+}
+
+// Attaches a middleware to a registered route, for the given methods only, or all of them if none is given
+// Middlewares run in the order they are attached
+func (s *Server) UseOnEndpoint(route string, middleware Middleware, methods ...string) error {
+	return s.recordRegistrationError(s.useOnEndpoint(route, middleware, methods))
+}
+
+func (s *Server) useOnEndpoint(route string, middleware Middleware, methods []string) error {
+
+	if middleware == nil {
+		return errors.New(fmt.Sprintf(`Middleware for route %s is nil`, route))
+	}
+
+	routers := []*router{s.router}
+	for _, hr := range s.hostRouters {
+		routers = append(routers, hr.router)
+	}
+
+	found := false
+	for _, r := range routers {
+		node, err := r.FindNodeByPattern(route)
+		if err == nil {
+			node.GetEndpoint().middlewares = append(node.GetEndpoint().middlewares, scopedMiddleware{middleware: middleware, methods: methods})
+			found = true
+		}
+	}
+
+	if !found {
+		return errors.New(fmt.Sprintf(`Route %s is not registered`, route))
+	}
+
+	return nil
+}
+
+// Wraps the implementation with the endpoint middlewares applying to the method, the first attached being the outermost
+func (e *endpoint) wrapWithMiddlewares(method string, implementation ResourceHandlerImplementation) ResourceHandlerImplementation {
+
+	for i := len(e.middlewares) - 1; i >= 0; i-- {
+		if e.middlewares[i].appliesTo(method) {
+			implementation = e.middlewares[i].middleware(implementation)
+		}
+	}
+
+	return implementation
+}
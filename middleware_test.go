@@ -0,0 +1,137 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Endpoint middleware tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// Middleware rejecting requests without the admin token
+func authTestMiddleware(next ResourceHandlerImplementation) ResourceHandlerImplementation {
+	return ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+		if context.Header.Get(`Authorization`) != `Bearer admin` {
+			return errorResult(NewHTTPError(http.StatusUnauthorized, `Unauthorized`))
+		}
+		return next.Execute(context)
+	})
+}
+
+// Middleware recording its name in trace before calling next
+func traceTestMiddleware(name string, trace *[]string) Middleware {
+	return func(next ResourceHandlerImplementation) ResourceHandlerImplementation {
+		return ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			*trace = append(*trace, name)
+			return next.Execute(context)
+		})
+	}
+}
+
+func TestMiddlewareScopedToMethods(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		method        string
+		body          string
+		authorization string
+		status        int
+	}{
+		{`public get`, `GET`, ``, ``, http.StatusOK},
+		{`post without credentials`, `POST`, `item`, ``, http.StatusUnauthorized},
+		{`post with credentials`, `POST`, `item`, `Bearer admin`, http.StatusOK},
+		{`delete without credentials`, `DELETE`, ``, ``, http.StatusUnauthorized},
+		{`delete with credentials`, `DELETE`, ``, `Bearer admin`, http.StatusOK},
+	}
+
+	post := textResourceHandler(`POST`, `created`)
+	post.ContentTypeIn = []string{`text/plain`}
+
+	s := NewServer(`/`, `:0`)
+	s.NewEndpoint(`/items`, textResourceHandler(`GET`, `items`), post, textResourceHandler(`DELETE`, `deleted`))
+	if err := s.UseOnEndpoint(`/items`, authTestMiddleware, `post`, `DELETE`); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range tests {
+		header := map[string]string{`Authorization`: test.authorization}
+		if test.body != `` {
+			header[`Content-Type`] = `text/plain`
+		}
+		recorder := serveTestRequest(s, test.method, `/items`, strings.NewReader(test.body), header)
+		if recorder.Code != test.status {
+			t.Errorf(`%s : expected %d, got %d %q`, test.name, test.status, recorder.Code, recorder.Body.String())
+		}
+	}
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+
+	tests := []struct {
+		method string
+		trace  string
+	}{
+		{`GET`, `logging, metrics`},
+		{`DELETE`, `logging, audit, metrics`},
+	}
+
+	trace := []string{}
+
+	s := NewServer(`/`, `:0`)
+	s.NewEndpoint(`/items`, textResourceHandler(`GET`, `items`), textResourceHandler(`DELETE`, `deleted`))
+	s.UseOnEndpoint(`/items`, traceTestMiddleware(`logging`, &trace))
+	s.UseOnEndpoint(`/items`, traceTestMiddleware(`audit`, &trace), `DELETE`)
+	s.UseOnEndpoint(`/items`, traceTestMiddleware(`metrics`, &trace))
+
+	for _, test := range tests {
+		trace = trace[:0]
+		serveTestRequest(s, test.method, `/items`, nil, nil)
+		if strings.Join(trace, `, `) != test.trace {
+			t.Errorf(`%s : ran %v, expected %s`, test.method, trace, test.trace)
+		}
+	}
+}
+
+func TestUseOnEndpointErrors(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		route      string
+		middleware Middleware
+		err        string
+	}{
+		{`nil middleware`, `/items`, nil, `Middleware for route /items is nil`},
+		{`unknown route`, `/users`, authTestMiddleware, `Route /users is not registered`},
+	}
+
+	for _, test := range tests {
+		s := NewServer(`/`, `:0`)
+		s.NewEndpoint(`/items`, textResourceHandler(`GET`, `items`))
+		if err := s.UseOnEndpoint(test.route, test.middleware); err == nil || err.Error() != test.err {
+			t.Errorf(`%s : expected %q, got %v`, test.name, test.err, err)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Accept based versioning tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestNegotiatedVersion(t *testing.T) {
+
+	tests := []struct {
+		accept      string
+		status      int
+		contentType string
+		body        string
+	}{
+		{`application/vnd.api.v1+json`, http.StatusOK, `application/vnd.api.v1+json`, `{"name":"Ada Lovelace"}`},
+		{`application/vnd.api.v2+json`, http.StatusOK, `application/vnd.api.v2+json`, `{"first":"Ada","last":"Lovelace"}`},
+		{`application/json`, http.StatusOK, `application/json`, `{"name":"Ada Lovelace"}`},
+		{`application/vnd.api.v3+json`, http.StatusBadRequest, ``, ``},
+	}
+
+	s := NewServer(`/`, `:0`)
+	s.NewEndpoint(`/users/ada`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`application/json`, `application/vnd.api.v1+json`, `application/vnd.api.v2+json`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+		if context.NegotiatedVersion() == `2` {
+			return ResourceHandlerResult{HttpStatus: http.StatusOK, Payload: map[string]string{`first`: `Ada`, `last`: `Lovelace`}}
+		}
+		return ResourceHandlerResult{HttpStatus: http.StatusOK, Payload: map[string]string{`name`: `Ada Lovelace`}}
+	})})
+
+	for _, test := range tests {
+		recorder := serveTestRequest(s, `GET`, `/users/ada`, nil, map[string]string{`Accept`: test.accept})
+		if recorder.Code != test.status {
+			t.Errorf(`%s : got %d, expected %d`, test.accept, recorder.Code, test.status)
+			continue
+		}
+		if test.status != http.StatusOK {
+			continue
+		}
+		if !strings.HasPrefix(recorder.Header().Get(`Content-Type`), test.contentType) || recorder.Body.String() != test.body {
+			t.Errorf(`%s : got %q %q, expected %q %q`, test.accept, recorder.Header().Get(`Content-Type`), recorder.Body.String(), test.contentType, test.body)
+		}
+	}
+}
+
+func TestNegotiatedVersionFormats(t *testing.T) {
+
+	tests := []struct {
+		contentType string
+		version     string
+	}{
+		{`application/vnd.api.v2+json`, `2`},
+		{`application/vnd.api+json; version=3`, `3`},
+		{`application/vnd.api+json`, ``},
+		{`application/json`, ``},
+		{``, ``},
+	}
+
+	for _, test := range tests {
+		contentType := test.contentType
+		context := &ResourceHandlerContext{ContentTypeOut: &contentType}
+		if version := context.NegotiatedVersion(); version != test.version {
+			t.Errorf(`%q : got %q, expected %q`, test.contentType, version, test.version)
+		}
+	}
+}
+
+func TestFindCodecBySuffix(t *testing.T) {
+
+	tests := []struct {
+		contentType string
+		found       bool
+	}{
+		{`application/json`, true},
+		{`application/vnd.api.v2+json`, true},
+		{`application/atom+xml`, true},
+		{`application/vnd.api.v2+yaml`, false},
+		{`text/plain`, false},
+	}
+
+	codecs := newDefaultCodecs()
+	for _, test := range tests {
+		if _, found := findCodec(codecs, test.contentType); found != test.found {
+			t.Errorf(`%s : got %t, expected %t`, test.contentType, found, test.found)
+		}
+	}
+}
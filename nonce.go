@@ -0,0 +1,125 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Replay protection : requests reusing a nonce of the same client within a window are rejected.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	const_nonce_unknown_client_scope = `unknown`
+)
+
+// Pluggable storage for the nonces seen, implementations must be safe for concurrent use
+type NonceStore interface {
+	// Records the nonce for ttl, returns false if it was already recorded and has not expired
+	Add(nonce string, ttl time.Duration) bool
+}
+
+// In memory NonceStore, expired nonces are swept from time to time as new ones are added
+type MemoryNonceStore struct {
+	mutex     sync.Mutex
+	expiresAt map[string]time.Time
+	nextSweep time.Time
+}
+
+func NewMemoryNonceStore() *MemoryNonceStore {
+	return &MemoryNonceStore{expiresAt: make(map[string]time.Time)}
+}
+
+func (m *MemoryNonceStore) Add(nonce string, ttl time.Duration) bool {
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := time.Now()
+
+	if now.After(m.nextSweep) {
+		for n, expiresAt := range m.expiresAt {
+			if now.After(expiresAt) {
+				delete(m.expiresAt, n)
+			}
+		}
+		m.nextSweep = now.Add(ttl)
+	}
+
+	expiresAt, ok := m.expiresAt[nonce]
+	if ok && !now.After(expiresAt) {
+		return false
+	}
+
+	m.expiresAt[nonce] = now.Add(ttl)
+
+	return true
+}
+
+// Requires every routed request to carry a nonce in the header, a nonce reused by the same client within window is answered 409
+// Requests without the header are answered 400 ; a nil store defaults to an in memory store
+// Clients are told apart by IP only, see SetTrustedProxies : clients sharing one, e.g. behind a NAT, or whose IP is unknown,
+// e.g. on a unix socket, share their nonces, which must be picked at random, e.g. UUIDs, so as not to collide
+func (s *Server) EnableNonceProtection(headerName string, window time.Duration, store NonceStore) {
+
+	if store == nil {
+		store = NewMemoryNonceStore()
+	}
+
+	Flog(FLOG_TYPE_ACTION, fmt.Sprintf("Enabling nonce protection on header %s\n", headerName))
+
+	s.nonceHeader = headerName
+	s.nonceWindow = window
+	s.nonceStore = store
+}
+
+// Returns false after answering 400 or 409 if the request nonce is missing or was already used
+func (s *Server) checkNonce(writer http.ResponseWriter, request *http.Request, requestId string) bool {
+
+	nonce := request.Header.Get(s.nonceHeader)
+	if nonce == `` {
+		message := fmt.Sprintf("Missing %s header", s.nonceHeader)
+		Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Missing %s header", requestId, s.nonceHeader))
+		s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusBadRequest, Body: bytes.NewBufferString(message)}, `text/plain`, requestId)
+		return false
+	}
+
+	// Scoped to the client IP, so that two clients may pick the same nonce
+	scope := const_nonce_unknown_client_scope
+	if ip := s.clientIP(request); ip != nil {
+		scope = ip.String()
+	}
+
+	if !s.nonceStore.Add(scope+` `+nonce, s.nonceWindow) {
+		message := fmt.Sprintf("Nonce was already used")
+		Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Nonce %s was already used", requestId, nonce))
+		s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusConflict, Body: bytes.NewBufferString(message)}, `text/plain`, requestId)
+		return false
+	}
+
+	return true
+}
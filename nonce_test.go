@@ -0,0 +1,148 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Nonce replay protection tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNonceProtection(t *testing.T) {
+
+	// In order, on the same server
+	tests := []struct {
+		name       string
+		remoteAddr string
+		nonce      string
+		query      string
+		status     int
+	}{
+		{`first use`, `192.0.2.1:1234`, `n-1`, ``, http.StatusOK},
+		{`replayed`, `192.0.2.1:1234`, `n-1`, ``, http.StatusConflict},
+		{`replayed from another port`, `192.0.2.1:5678`, `n-1`, ``, http.StatusConflict},
+		{`same nonce from another client`, `192.0.2.2:1234`, `n-1`, ``, http.StatusOK},
+		{`missing nonce`, `192.0.2.1:1234`, ``, ``, http.StatusBadRequest},
+		{`rejected on validation`, `192.0.2.1:1234`, `n-2`, `?limit=abc`, http.StatusBadRequest},
+		{`sent again once fixed`, `192.0.2.1:1234`, `n-2`, `?limit=5`, http.StatusOK},
+		{`unknown client`, `@`, `n-3`, ``, http.StatusOK},
+		{`replayed by an unknown client`, ``, `n-3`, ``, http.StatusConflict},
+		{`same nonce from a known client`, `192.0.2.1:1234`, `n-3`, ``, http.StatusOK},
+		{`other nonce from an unknown client`, `@`, `n-4`, ``, http.StatusOK},
+	}
+
+	handler := textResourceHandler(`GET`, `transfers`)
+	handler.QueryParameters = map[string]QueryParameter{`limit`: {Kind: QueryParameterInt, DefaultValue: `10`}}
+
+	s := NewServer(`/`, `:0`)
+	s.EnableNonceProtection(`X-Nonce`, time.Minute, nil)
+	s.NewEndpoint(`/transfers`, handler)
+
+	for _, test := range tests {
+		request := httptest.NewRequest(`GET`, `/transfers`+test.query, nil)
+		request.RemoteAddr = test.remoteAddr
+		request.Header.Set(`Accept`, `*/*`)
+		request.Header.Set(`X-Nonce`, test.nonce)
+
+		recorder := httptest.NewRecorder()
+		s.ServeHTTP(recorder, request)
+
+		if recorder.Code != test.status {
+			t.Errorf(`%s : expected %d, got %d %q`, test.name, test.status, recorder.Code, recorder.Body.String())
+		}
+	}
+}
+
+func TestNonceScope(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		scoped     string
+	}{
+		{`ipv4`, `192.0.2.1:1234`, `192.0.2.1 n-1`},
+		{`ipv6`, `[2001:db8::1]:1234`, `2001:db8::1 n-1`},
+		{`unix socket`, `@`, `unknown n-1`},
+		{`no address`, ``, `unknown n-1`},
+	}
+
+	for _, test := range tests {
+		store := &recordingNonceStore{}
+		s := NewServer(`/`, `:0`)
+		s.EnableNonceProtection(`X-Nonce`, time.Minute, store)
+
+		request := httptest.NewRequest(`GET`, `/`, nil)
+		request.RemoteAddr = test.remoteAddr
+		request.Header.Set(`X-Nonce`, `n-1`)
+		s.checkNonce(httptest.NewRecorder(), request, `o`)
+
+		if len(store.nonces) != 1 || store.nonces[0] != test.scoped {
+			t.Errorf(`%s : stored %q, expected %q`, test.name, store.nonces, test.scoped)
+		}
+	}
+}
+
+// NonceStore recording the scoped nonces it is given
+type recordingNonceStore struct {
+	nonces []string
+}
+
+func (r *recordingNonceStore) Add(nonce string, ttl time.Duration) bool {
+	r.nonces = append(r.nonces, nonce)
+	return true
+}
+
+func TestMemoryNonceStore(t *testing.T) {
+
+	store := NewMemoryNonceStore()
+
+	tests := []struct {
+		name  string
+		nonce string
+		wait  time.Duration
+		added bool
+	}{
+		{`new`, `a`, 0, true},
+		{`within the window`, `a`, 0, false},
+		{`other nonce`, `b`, 0, true},
+		{`after the window`, `a`, 30 * time.Millisecond, true},
+	}
+
+	for _, test := range tests {
+		time.Sleep(test.wait)
+		if added := store.Add(test.nonce, 20*time.Millisecond); added != test.added {
+			t.Errorf(`%s : added %t, expected %t`, test.name, added, test.added)
+		}
+	}
+
+	// Expired nonces were swept
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	if _, ok := store.expiresAt[`b`]; ok {
+		t.Error(`expired nonce b was kept`)
+	}
+}
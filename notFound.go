@@ -0,0 +1,119 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      404 responses negotiated with the Accept header, from configurable templates.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+)
+
+type notFoundTemplate struct {
+	contentType string
+	template    interface {
+		Execute(writer io.Writer, data interface{}) error
+	}
+}
+
+// Data given to not found templates
+type NotFoundData struct {
+	Path string
+}
+
+// Functions available to not found templates other than HTML ones
+var notFoundTemplateFuncs = template.FuncMap{
+	// Quoted and escaped JSON string, e.g. {"path":{{json .Path}}}
+	`json`: func(value string) (string, error) {
+		encoded, err := json.Marshal(value)
+		return string(encoded), err
+	},
+}
+
+// Adds a template rendering the 404 body for the given content type, executed with a NotFoundData
+// HTML templates are escaped, the others are not : a JSON template must quote values with the json function,
+// e.g. {"path":{{json .Path}}}
+// The first template added is used when anything is accepted, and the first of a type for a range such as application/*
+func (s *Server) SetNotFoundTemplate(contentType string, text string) error {
+
+	var err error
+	t := notFoundTemplate{contentType: contentType}
+
+	if strings.HasPrefix(contentType, `text/html`) {
+		t.template, err = htmltemplate.New(contentType).Parse(text)
+	} else {
+		t.template, err = template.New(contentType).Funcs(notFoundTemplateFuncs).Parse(text)
+	}
+	if err != nil {
+		return err
+	}
+
+	s.notFoundTemplates = append(s.notFoundTemplates, t)
+
+	return nil
+}
+
+// Renders the 404 with the template matching the Accept header best, or as plain text if none does
+func (s *Server) renderNotFound(writer http.ResponseWriter, request *http.Request, message string, requestId string) {
+
+	if len(s.notFoundTemplates) > 0 {
+
+		acceptParser, err := newAcceptHeaderParser(request.Header.Get(`Accept`))
+		if err == nil {
+			for _, acceptElement := range acceptParser.contentTypes {
+				for _, t := range s.notFoundTemplates {
+					if acceptElement.priority <= 0 || !matchesMediaRange(acceptElement.contentType, t.contentType) {
+						continue
+					}
+					body := new(bytes.Buffer)
+					err := t.template.Execute(body, NotFoundData{Path: request.URL.Path})
+					if err != nil {
+						Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Could not render the not found template : %s", requestId, err.Error()))
+						break
+					}
+					s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusNotFound, Body: body}, t.contentType, requestId)
+					return
+				}
+			}
+		}
+	}
+
+	s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusNotFound, Body: bytes.NewBufferString(message)}, `text/plain`, requestId)
+}
+
+// True if the content type is within the media range of an Accept header, e.g. */*, application/* or application/json
+func matchesMediaRange(mediaRange string, contentType string) bool {
+
+	if mediaRange == `*/*` || mediaRange == contentType {
+		return true
+	}
+
+	return strings.HasSuffix(mediaRange, `/*`) && strings.HasPrefix(contentType, strings.TrimSuffix(mediaRange, `*`))
+}
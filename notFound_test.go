@@ -0,0 +1,75 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Not found template tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestNotFoundTemplates(t *testing.T) {
+
+	s := NewServer(`/`, `:0`)
+	if err := s.SetNotFoundTemplate(`application/json`, `{"error":"not found","path":{{json .Path}}}`); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetNotFoundTemplate(`text/html`, `<h1>Not found {{.Path}}</h1>`); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		target      string
+		accept      string
+		contentType string
+		body        string
+	}{
+		{`json`, `/a%22b`, `application/json`, `application/json`, `{"error":"not found","path":"/a\"b"}`},
+		{`json range`, `/a`, `application/*`, `application/json`, `{"error":"not found","path":"/a"}`},
+		{`html`, `/x%3Cy%3E`, `text/html,application/xhtml+xml;q=0.9,*/*;q=0.8`, `text/html`, `<h1>Not found /x&lt;y&gt;</h1>`},
+		{`html range`, `/a`, `text/*`, `text/html`, `<h1>Not found /a</h1>`},
+		{`anything`, `/a`, `*/*`, `application/json`, `{"error":"not found","path":"/a"}`},
+		{`excluded`, `/a`, `application/json;q=0, text/html`, `text/html`, `<h1>Not found /a</h1>`},
+		{`no template`, `/a`, `image/png`, `text/plain`, `Could not find route for /a`},
+	}
+
+	for _, test := range tests {
+		recorder := serveTestRequest(s, `GET`, test.target, nil, map[string]string{`Accept`: test.accept})
+		if recorder.Code != http.StatusNotFound {
+			t.Errorf(`%s : expected 404, got %d`, test.name, recorder.Code)
+		}
+		if contentType := recorder.Header().Get(`Content-Type`); contentType != test.contentType {
+			t.Errorf(`%s : Content-Type %q, expected %q`, test.name, contentType, test.contentType)
+		}
+		if recorder.Body.String() != test.body {
+			t.Errorf(`%s : body %q, expected %q`, test.name, recorder.Body.String(), test.body)
+		}
+		if test.contentType == `application/json` && !json.Valid(recorder.Body.Bytes()) {
+			t.Errorf(`%s : invalid JSON %q`, test.name, recorder.Body.String())
+		}
+	}
+}
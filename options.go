@@ -0,0 +1,127 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Automatic OPTIONS responses : Allow header and optional endpoint discovery body.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	const_options_discovery_content_type = `application/json`
+)
+
+type optionsDiscovery struct {
+	Route   string                   `json:"route"`
+	Methods []optionsDiscoveryMethod `json:"methods"`
+}
+
+type optionsDiscoveryMethod struct {
+	Method         string   `json:"method"`
+	ContentTypeIn  []string `json:"contentTypeIn"`
+	ContentTypeOut []string `json:"contentTypeOut"`
+}
+
+// True if none of the endpoint resource handlers implements OPTIONS itself
+func (e *endpoint) needsAutomaticOptions() bool {
+	for _, r := range e.resourceHandlers {
+		if r.Method == `OPTIONS` {
+			return false
+		}
+	}
+	return true
+}
+
+// Methods available on the endpoint, in registration order, OPTIONS included
+func (e *endpoint) allowedMethods() []string {
+
+	methods := []string{}
+	seen := make(map[string]bool)
+
+	for _, r := range e.resourceHandlers {
+		if !seen[r.Method] {
+			seen[r.Method] = true
+			methods = append(methods, r.Method)
+		}
+	}
+
+	if !seen[`OPTIONS`] {
+		methods = append(methods, `OPTIONS`)
+	}
+
+	return methods
+}
+
+func (s *Server) serveAutomaticOptions(writer http.ResponseWriter, request *http.Request, endp *endpoint, requestId string) {
+
+	writer.Header().Set(`Allow`, strings.Join(endp.allowedMethods(), `, `))
+
+	result := &ResourceHandlerResult{HttpStatus: http.StatusOK}
+
+	if s.optionsDiscoveryEnabled && acceptsContentType(request.Header.Get(`Accept`), const_options_discovery_content_type) {
+
+		discovery := optionsDiscovery{Route: endp.GetRoute(), Methods: []optionsDiscoveryMethod{}}
+		for _, r := range endp.GetResourceHandlers() {
+			discovery.Methods = append(discovery.Methods, optionsDiscoveryMethod{Method: r.Method, ContentTypeIn: append([]string{}, r.ContentTypeIn...), ContentTypeOut: append([]string{}, r.ContentTypeOut...)})
+		}
+
+		jsonDiscovery, err := json.Marshal(discovery)
+		if err != nil {
+			message := fmt.Sprintf("Could not render OPTIONS discovery : %s", err.Error())
+			Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Could not render OPTIONS discovery : %s", requestId, err.Error()))
+			s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusInternalServerError, Body: bytes.NewBufferString(message)}, `text/plain`, requestId)
+			return
+		}
+
+		result.Body = bytes.NewBuffer(jsonDiscovery)
+	}
+
+	s.renderResourceResult(writer, result, const_options_discovery_content_type, requestId)
+}
+
+// True if the given Accept header value allows the content type, a missing Accept header allows everything
+func acceptsContentType(acceptValue string, contentType string) bool {
+
+	if acceptValue == `` {
+		return true
+	}
+
+	acceptParser, err := newAcceptHeaderParser(acceptValue)
+	if err != nil {
+		return false
+	}
+
+	for _, acceptElement := range acceptParser.contentTypes {
+		if acceptElement.priority > 0 && (acceptElement.contentType == contentType || acceptElement.contentType == `*/*`) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,79 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Automatic OPTIONS tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestAutomaticOptions(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		discovery bool
+		accept    string
+		body      string
+	}{
+		{`allow header only`, false, `*/*`, ``},
+		{`discovery`, true, `application/json`, `{"route":"/users","methods":[{"method":"GET","contentTypeIn":[],"contentTypeOut":["text/plain"]},{"method":"POST","contentTypeIn":["application/json"],"contentTypeOut":["text/plain"]}]}`},
+		{`discovery not accepted`, true, `text/html`, ``},
+	}
+
+	for _, test := range tests {
+		post := textResourceHandler(`POST`, `created`)
+		post.ContentTypeIn = []string{`application/json`}
+
+		s := NewServer(`/`, `:0`)
+		s.EnableAutomaticOptions(true)
+		s.EnableOptionsDiscovery(test.discovery)
+		s.NewEndpoint(`/users`, textResourceHandler(`GET`, `users`), post)
+
+		recorder := serveTestRequest(s, `OPTIONS`, `/users`, nil, map[string]string{`Accept`: test.accept})
+
+		if recorder.Code != http.StatusOK {
+			t.Errorf(`%s : expected 200, got %d`, test.name, recorder.Code)
+		}
+		if recorder.Header().Get(`Allow`) != `GET, POST, OPTIONS` {
+			t.Errorf(`%s : Allow %q`, test.name, recorder.Header().Get(`Allow`))
+		}
+		if recorder.Body.String() != test.body {
+			t.Errorf(`%s : body %q, expected %q`, test.name, recorder.Body.String(), test.body)
+		}
+	}
+}
+
+func TestAutomaticOptionsLeavesImplementedOptions(t *testing.T) {
+
+	s := NewServer(`/`, `:0`)
+	s.EnableAutomaticOptions(true)
+	s.NewEndpoint(`/users`, textResourceHandler(`GET`, `users`), textResourceHandler(`OPTIONS`, `custom`))
+
+	recorder := serveTestRequest(s, `OPTIONS`, `/users`, nil, nil)
+	if recorder.Body.String() != `custom` || recorder.Header().Get(`Allow`) != `` {
+		t.Errorf(`got %q with Allow %q`, recorder.Body.String(), recorder.Header().Get(`Allow`))
+	}
+}
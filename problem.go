@@ -0,0 +1,75 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Validation errors rendering, optionally as RFC 7807 application/problem+json.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+)
+
+const (
+	const_problem_json_content_type = `application/problem+json`
+	const_problem_default_type      = `about:blank`
+)
+
+// RFC 7807 problem details
+type Problem struct {
+	Type          string                `json:"type"`
+	Title         string                `json:"title"`
+	Status        int                   `json:"status"`
+	Detail        string                `json:"detail,omitempty"`
+	InvalidParams []ProblemInvalidParam `json:"invalid-params,omitempty"`
+}
+
+type ProblemInvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// Renders client validation errors ( query parameters, headers, body ) as application/problem+json
+func (s *Server) EnableProblemJSON(b bool) {
+	s.problemJSONEnabled = b
+}
+
+// Renders a 400 validation error, as plain text or as problem+json if enabled
+func (s *Server) renderValidationError(writer http.ResponseWriter, message string, invalidParams []ProblemInvalidParam, requestId string) {
+
+	if !s.problemJSONEnabled {
+		s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusBadRequest, Body: bytes.NewBufferString(message)}, `text/plain`, requestId)
+		return
+	}
+
+	problem := Problem{Type: const_problem_default_type, Title: http.StatusText(http.StatusBadRequest), Status: http.StatusBadRequest, Detail: message, InvalidParams: invalidParams}
+
+	jsonProblem, err := json.Marshal(problem)
+	if err != nil {
+		s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusBadRequest, Body: bytes.NewBufferString(message)}, `text/plain`, requestId)
+		return
+	}
+
+	s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusBadRequest, Body: bytes.NewBuffer(jsonProblem)}, const_problem_json_content_type, requestId)
+}
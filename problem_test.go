@@ -0,0 +1,95 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Validation error rendering tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestProblemJSON(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		target        string
+		header        map[string]string
+		invalidParams []ProblemInvalidParam
+		detail        string
+	}{
+		{`query parameter`, `/users/42?limit=many`, nil, []ProblemInvalidParam{{Name: `limit`, Reason: `must be of kind int`}}, `Query parameter limit must be of kind int`},
+		{`route variable`, `/users/abc`, nil, []ProblemInvalidParam{{Name: `id`, Reason: `must be of kind digits`}}, `Route variable 'id' must be of kind 'digits', got 'abc'`},
+		{`header parameter`, `/users/42`, map[string]string{`X-Tenant`: ``}, []ProblemInvalidParam{{Name: `X-Tenant`, Reason: `Header X-Tenant is required`}}, `Header X-Tenant is required`},
+	}
+
+	for _, test := range tests {
+		handler := textResourceHandler(`GET`, `user`)
+		handler.QueryParameters = map[string]QueryParameter{`limit`: {Kind: QueryParameterInt, DefaultValue: `10`}}
+		handler.HeaderParameters = map[string]HeaderParameter{`X-Tenant`: {Required: true}}
+
+		s := NewServer(`/`, `:0`)
+		s.EnableProblemJSON(true)
+		s.NewRouteVariableType(`digits`, digitsRouteVariableType{})
+		s.NewEndpoint(`/users/{id:digits}`, handler)
+
+		header := map[string]string{`X-Tenant`: `acme`}
+		for name, value := range test.header {
+			header[name] = value
+		}
+		recorder := serveTestRequest(s, `GET`, test.target, nil, header)
+
+		if recorder.Code != http.StatusBadRequest || recorder.Header().Get(`Content-Type`) != `application/problem+json` {
+			t.Errorf(`%s : got %d as %q`, test.name, recorder.Code, recorder.Header().Get(`Content-Type`))
+			continue
+		}
+
+		var problem Problem
+		if err := json.Unmarshal(recorder.Body.Bytes(), &problem); err != nil {
+			t.Errorf(`%s : %s`, test.name, err.Error())
+			continue
+		}
+		if problem.Type != `about:blank` || problem.Status != http.StatusBadRequest || problem.Title != `Bad Request` || problem.Detail != test.detail {
+			t.Errorf(`%s : problem %+v`, test.name, problem)
+		}
+		if len(problem.InvalidParams) != len(test.invalidParams) || (len(test.invalidParams) > 0 && problem.InvalidParams[0] != test.invalidParams[0]) {
+			t.Errorf(`%s : invalid params %+v, expected %+v`, test.name, problem.InvalidParams, test.invalidParams)
+		}
+	}
+}
+
+func TestValidationErrorAsPlainText(t *testing.T) {
+
+	handler := textResourceHandler(`GET`, `users`)
+	handler.QueryParameters = map[string]QueryParameter{`limit`: {Kind: QueryParameterInt, DefaultValue: `10`}}
+
+	s := NewServer(`/`, `:0`)
+	s.NewEndpoint(`/users`, handler)
+
+	recorder := serveTestRequest(s, `GET`, `/users?limit=many`, nil, nil)
+	if recorder.Code != http.StatusBadRequest || recorder.Header().Get(`Content-Type`) != `text/plain` || recorder.Body.String() != `Query parameter limit must be of kind int` {
+		t.Errorf(`got %d %q as %q`, recorder.Code, recorder.Body.String(), recorder.Header().Get(`Content-Type`))
+	}
+}
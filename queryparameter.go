@@ -27,7 +27,9 @@ package gorip
 
 import (
 	"github.com/sigu-399/goformatvalidation"
+	"net/url"
 	"strconv"
+	"strings"
 )
 
 const (
@@ -38,9 +40,50 @@ const (
 )
 
 type QueryParameter struct {
-	Kind            string
-	DefaultValue    string
-	FormatValidator goformatvalidation.Validator
+	Kind             string
+	DefaultValue     string
+	FormatValidator  goformatvalidation.Validator
+	FormatValidators []goformatvalidation.Validator // run in order after FormatValidator, the first failure is reported
+	Aliases          []string                       // alternative names, read in order when the canonical name is not given
+	Trim             bool                           // surrounding spaces are removed before validation
+	Lowercase        bool                           // value is lowercased before validation
+}
+
+// Returns the first format validator rejecting the value, nil if the value is valid
+func (q *QueryParameter) FindFailingValidator(value string) goformatvalidation.Validator {
+	return findFailingValidator(append([]goformatvalidation.Validator{q.FormatValidator}, q.FormatValidators...), value)
+}
+
+// Applies the normalization options of the parameter to a given value
+func (q *QueryParameter) Normalize(value string) string {
+
+	if q.Trim {
+		value = strings.TrimSpace(value)
+	}
+
+	if q.Lowercase {
+		value = strings.ToLower(value)
+	}
+
+	return value
+}
+
+// Value given for the parameter under its canonical name, or else under its first given alias
+func (q *QueryParameter) GetValue(name string, values url.Values) string {
+
+	value := values.Get(name)
+	if value != `` {
+		return value
+	}
+
+	for _, alias := range q.Aliases {
+		value = values.Get(alias)
+		if value != `` {
+			return value
+		}
+	}
+
+	return ``
 }
 
 func (q *QueryParameter) IsValidType(value string) bool {
@@ -0,0 +1,127 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Query parameter tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// Server whose GET /items answers the value of the name query parameter
+func newQueryParameterTestServer(name string, queryParameter QueryParameter) *Server {
+
+	s := NewServer(`/`, `:0`)
+	s.NewEndpoint(`/items`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/plain`}, QueryParameters: map[string]QueryParameter{name: queryParameter}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+		return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(context.QueryParameters[name])}
+	})})
+
+	return s
+}
+
+func TestQueryParameterAliases(t *testing.T) {
+
+	s := newQueryParameterTestServer(`limit`, QueryParameter{Kind: QueryParameterInt, DefaultValue: `10`, Aliases: []string{`l`, `max`}})
+
+	tests := []struct {
+		query  string
+		status int
+		value  string
+	}{
+		{`?limit=5`, http.StatusOK, `5`},
+		{`?l=7`, http.StatusOK, `7`},
+		{`?max=8`, http.StatusOK, `8`},
+		{`?l=7&max=8`, http.StatusOK, `7`},
+		{`?max=8&limit=5`, http.StatusOK, `5`},
+		{``, http.StatusOK, `10`},
+		{`?l=many`, http.StatusBadRequest, `Query parameter limit must be of kind int`},
+	}
+
+	for _, test := range tests {
+		recorder := serveTestRequest(s, `GET`, `/items`+test.query, nil, nil)
+		if recorder.Code != test.status || recorder.Body.String() != test.value {
+			t.Errorf(`%s : got %d %q, expected %d %q`, test.query, recorder.Code, recorder.Body.String(), test.status, test.value)
+		}
+	}
+}
+
+func TestQueryParameterNormalization(t *testing.T) {
+
+	tests := []struct {
+		name           string
+		queryParameter QueryParameter
+		query          string
+		status         int
+		value          string
+	}{
+		{`as given`, QueryParameter{Kind: QueryParameterString}, `?sort=%20Name%20`, http.StatusOK, ` Name `},
+		{`trimmed`, QueryParameter{Kind: QueryParameterString, Trim: true}, `?sort=%20Name%20`, http.StatusOK, `Name`},
+		{`lowercased`, QueryParameter{Kind: QueryParameterString, Lowercase: true}, `?sort=%20Name%20`, http.StatusOK, ` name `},
+		{`trimmed and lowercased`, QueryParameter{Kind: QueryParameterString, Trim: true, Lowercase: true}, `?sort=%20Name%20`, http.StatusOK, `name`},
+		{`normalized before validation`, QueryParameter{Kind: QueryParameterBool, Trim: true, Lowercase: true}, `?sort=%20TRUE`, http.StatusOK, `true`},
+		{`not normalized`, QueryParameter{Kind: QueryParameterBool}, `?sort=%20TRUE`, http.StatusBadRequest, `Query parameter sort must be of kind bool`},
+		{`blank falls back to the default`, QueryParameter{Kind: QueryParameterString, DefaultValue: `id`, Trim: true}, `?sort=%20%20`, http.StatusOK, `id`},
+	}
+
+	for _, test := range tests {
+		s := newQueryParameterTestServer(`sort`, test.queryParameter)
+		recorder := serveTestRequest(s, `GET`, `/items`+test.query, nil, nil)
+		if recorder.Code != test.status || recorder.Body.String() != test.value {
+			t.Errorf(`%s : got %d %q, expected %d %q`, test.name, recorder.Code, recorder.Body.String(), test.status, test.value)
+		}
+	}
+}
+
+func TestDebugLogQueryParameters(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		enabled bool
+		query   string
+		logged  string
+	}{
+		{`disabled`, false, `?limt=5`, ``},
+		{`typo`, true, `?limt=5`, `declared : [limit] supplied : [limt] ignored : [limt]`},
+		{`alias`, true, `?max=5&sort=name`, `declared : [limit] supplied : [max, sort] ignored : [sort]`},
+		{`nothing ignored`, true, `?limit=5`, `declared : [limit] supplied : [limit] ignored : []`},
+	}
+
+	for _, test := range tests {
+		s := newQueryParameterTestServer(`limit`, QueryParameter{Kind: QueryParameterInt, DefaultValue: `10`, Aliases: []string{`max`}})
+		s.DebugEnableLogQueryParameters(test.enabled)
+
+		logged := captureLog(func() {
+			serveTestRequest(s, `GET`, `/items`+test.query, nil, nil)
+		})
+		if test.logged == `` && strings.Contains(logged, `Query parameters declared`) {
+			t.Errorf(`%s : query parameters logged while disabled`, test.name)
+		}
+		if !strings.Contains(logged, test.logged) {
+			t.Errorf(`%s : expected %q in the log %q`, test.name, test.logged, logged)
+		}
+	}
+}
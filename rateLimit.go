@@ -0,0 +1,119 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Fixed window rate limiting, keyed by any value of the request, e.g. a route variable.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Returns the key a request is counted under, requests with an empty key are not limited
+type RateLimitKeyFunc func(ctx *ResourceHandlerContext) string
+
+// Counts requests per value of the route variable, e.g. per tenant for /tenants/{tenantId}/...
+func RouteVariableRateLimitKey(name string) RateLimitKeyFunc {
+	return func(ctx *ResourceHandlerContext) string {
+		return ctx.RouteVariables[name]
+	}
+}
+
+type rateLimitWindow struct {
+	count   int
+	resetAt time.Time
+}
+
+type rateLimiter struct {
+	mutex     sync.Mutex
+	limit     int
+	window    time.Duration
+	windows   map[string]*rateLimitWindow
+	nextSweep time.Time
+}
+
+// Counts a request under the key, returns false and the time left in the window when over the limit
+func (l *rateLimiter) allow(key string, now time.Time) (bool, time.Duration) {
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	// Expired windows are dropped once per window, so that the counters do not grow with every key ever seen
+	if !now.Before(l.nextSweep) {
+		for otherKey, other := range l.windows {
+			if !now.Before(other.resetAt) {
+				delete(l.windows, otherKey)
+			}
+		}
+		l.nextSweep = now.Add(l.window)
+	}
+
+	w, ok := l.windows[key]
+	if !ok || !now.Before(w.resetAt) {
+		w = &rateLimitWindow{resetAt: now.Add(l.window)}
+		l.windows[key] = w
+	}
+
+	if w.count >= l.limit {
+		return false, w.resetAt.Sub(now)
+	}
+
+	w.count++
+
+	return true, 0
+}
+
+// Middleware allowing limit requests per key and window, further ones get a 429 with a Retry-After header
+// Attach it with UseOnEndpoint, each call creating its own counters ; a limit <= 0 does not limit
+func NewRateLimitMiddleware(limit int, window time.Duration, key RateLimitKeyFunc) Middleware {
+
+	if limit <= 0 {
+		return func(next ResourceHandlerImplementation) ResourceHandlerImplementation {
+			return next
+		}
+	}
+
+	limiter := &rateLimiter{limit: limit, window: window, windows: make(map[string]*rateLimitWindow)}
+
+	return func(next ResourceHandlerImplementation) ResourceHandlerImplementation {
+		return ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+
+			k := key(context)
+			if k == `` {
+				return next.Execute(context)
+			}
+
+			allowed, retryAfter := limiter.allow(k, time.Now())
+			if !allowed {
+				message := fmt.Sprintf("Rate limit of %d requests per %s exceeded", limit, window)
+				return ResourceHandlerResult{HttpStatus: http.StatusTooManyRequests, ContentType: `text/plain`, Body: bytes.NewBufferString(message)}.WithRetryAfter(retryAfter)
+			}
+
+			return next.Execute(context)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Rate limiting tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		limit    int
+		requests []string
+		statuses []int
+	}{
+		{`within the limit`, 2, []string{`a`, `a`}, []int{http.StatusOK, http.StatusOK}},
+		{`over the limit`, 2, []string{`a`, `a`, `a`}, []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}},
+		{`per key`, 1, []string{`a`, `b`, `a`}, []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}},
+		{`no limit`, 0, []string{`a`, `a`, `a`}, []int{http.StatusOK, http.StatusOK, http.StatusOK}},
+		{`negative limit`, -1, []string{`a`}, []int{http.StatusOK}},
+	}
+
+	for _, test := range tests {
+		s := NewServer(`/`, `:0`)
+		s.NewRouteVariableType(`any`, anyRouteVariableType{})
+		s.NewEndpoint(`/tenants/{tenantId:any}`, textResourceHandler(`GET`, `tenant`))
+		s.UseOnEndpoint(`/tenants/{tenantId:any}`, NewRateLimitMiddleware(test.limit, time.Minute, RouteVariableRateLimitKey(`tenantId`)))
+
+		for i, tenant := range test.requests {
+			recorder := serveTestRequest(s, `GET`, fmt.Sprintf(`/tenants/%s`, tenant), nil, nil)
+			if recorder.Code != test.statuses[i] {
+				t.Errorf(`%s : request %d expected %d, got %d`, test.name, i, test.statuses[i], recorder.Code)
+			}
+			if recorder.Code == http.StatusTooManyRequests && recorder.Header().Get(`Retry-After`) != `60` {
+				t.Errorf(`%s : Retry-After %q`, test.name, recorder.Header().Get(`Retry-After`))
+			}
+		}
+	}
+}
+
+func TestRateLimiterSweepsOncePerWindow(t *testing.T) {
+
+	now := time.Now()
+	limiter := &rateLimiter{limit: 1, window: time.Second, windows: make(map[string]*rateLimitWindow)}
+
+	limiter.allow(`a`, now)
+	limiter.allow(`b`, now.Add(500*time.Millisecond))
+	limiter.allow(`c`, now.Add(time.Second))
+
+	// b expired, but is kept until the next sweep, a window after the one dropping a
+	limiter.allow(`c`, now.Add(1600*time.Millisecond))
+	if _, ok := limiter.windows[`a`]; ok {
+		t.Error(`a was not swept`)
+	}
+	if _, ok := limiter.windows[`b`]; !ok {
+		t.Error(`b was swept before the next sweep`)
+	}
+
+	// An expired key starts a new window
+	if allowed, _ := limiter.allow(`b`, now.Add(1700*time.Millisecond)); !allowed {
+		t.Error(`b was not allowed in a new window`)
+	}
+
+	limiter.allow(`d`, now.Add(2800*time.Millisecond))
+	for _, key := range []string{`a`, `b`, `c`} {
+		if _, ok := limiter.windows[key]; ok {
+			t.Errorf(`%s was not swept`, key)
+		}
+	}
+	if len(limiter.windows) != 1 {
+		t.Errorf(`%d windows left, expected 1`, len(limiter.windows))
+	}
+}
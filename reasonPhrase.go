@@ -0,0 +1,244 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Custom reason phrase in the status line, written on the hijacked connection.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Writes the whole response on the hijacked connection so that the status line carries result.ReasonPhrase,
+// with the HTTP/1.x version of the request ; the server wrappers are unwrapped down to the connection and stood in for :
+// the body is compressed if it would have been, the metrics get the status and the bytes written, a write error is
+// reported to the write error handler and the body of a HEAD response is dropped
+// The connection is then handed back to the http server for the next requests, unless the request asked to close it
+// Returns false, nothing being written, when the connection is not a plain HTTP/1.x one that can be hijacked,
+// e.g. HTTP/2 or TLS : the standard status text is used
+func (s *Server) renderWithReasonPhrase(writer http.ResponseWriter, result *ResourceHandlerResult, contentType string, ctx *ResourceHandlerContext, requestId string) bool {
+
+	if ctx == nil || ctx.TLS != nil {
+		return false
+	}
+
+	if strings.ContainsAny(result.ReasonPhrase, "\r\n") {
+		Flog(FLOG_TYPE_WARNING, fmt.Sprintf("%s Invalid reason phrase ignored", requestId))
+		return false
+	}
+
+	// Serves the next requests of the connection
+	httpServer, ok := ctx.Context.Value(http.ServerContextKey).(*http.Server)
+	if !ok {
+		return false
+	}
+
+	conn, bufferedConn, err := http.NewResponseController(writer).Hijack()
+	if errors.Is(err, http.ErrNotSupported) {
+		return false
+	}
+	if err != nil {
+		Flog(FLOG_TYPE_WARNING, fmt.Sprintf("%s Could not hijack the connection for the reason phrase : %s", requestId, err.Error()))
+		return false
+	}
+
+	wrappers := findHijackedWriters(writer)
+
+	body := []byte{}
+	if result.Body != nil {
+		body = result.Body.Bytes()
+	}
+
+	header := writer.Header().Clone()
+	if len(body) > 0 {
+		header.Set(`Content-Type`, contentType)
+	}
+	if wrappers.compressor != nil && len(body) > 0 && header.Get(`Content-Encoding`) == `` && wrappers.compressor.compressible(contentType) {
+		compressed := new(bytes.Buffer)
+		gzipWriter := gzip.NewWriter(compressed)
+		gzipWriter.Write(body)
+		gzipWriter.Close()
+		body = compressed.Bytes()
+		header.Set(`Content-Encoding`, `gzip`)
+		header.Add(`Vary`, `Accept-Encoding`)
+	}
+	header.Set(`Content-Length`, strconv.Itoa(len(body)))
+
+	proto := `HTTP/1.1`
+	keepAlive := !hasConnectionToken(ctx.Header, `close`)
+	if ctx.proto == `HTTP/1.0` {
+		proto = ctx.proto
+		keepAlive = hasConnectionToken(ctx.Header, `keep-alive`)
+		if keepAlive {
+			header.Set(`Connection`, `keep-alive`)
+		}
+	}
+	if !keepAlive {
+		header.Set(`Connection`, `close`)
+	}
+
+	fmt.Fprintf(bufferedConn, "%s %d %s\r\n", proto, result.HttpStatus, result.ReasonPhrase)
+	header.Write(bufferedConn)
+	bufferedConn.WriteString("\r\n")
+
+	if len(body) > 0 && !wrappers.head {
+		bufferedConn.Write(body)
+	}
+
+	err = bufferedConn.Flush()
+	if err != nil {
+		Flog(FLOG_TYPE_INFO, fmt.Sprintf("%s Error while writing the body %s", requestId, err.Error()))
+		if wrappers.writeErrors != nil && wrappers.writeErrors.err == nil {
+			wrappers.writeErrors.err = err
+		}
+	}
+
+	if wrappers.recorder != nil {
+		wrappers.recorder.httpStatus = result.HttpStatus
+		if !wrappers.head {
+			wrappers.recorder.bytesWritten += int64(len(body))
+		}
+	}
+
+	if !keepAlive || err != nil {
+		conn.Close()
+		return true
+	}
+
+	// Deadlines of this request may be set, the server sets those of the next ones
+	conn.SetDeadline(time.Time{})
+	go httpServer.Serve(newHandedBackListener(conn, bufferedConn.Reader))
+
+	return true
+}
+
+// True if the Connection header of the request lists the token, e.g. close
+func hasConnectionToken(header http.Header, token string) bool {
+	for _, value := range header.Values(`Connection`) {
+		for _, element := range strings.Split(value, `,`) {
+			if strings.EqualFold(strings.TrimSpace(element), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Wrappers of the writer done without once the connection is hijacked
+type hijackedWriters struct {
+	head        bool // answers a HEAD request
+	recorder    *responseWriterRecorder
+	compressor  *gzipResponseWriter
+	writeErrors *writeErrorRecorder
+}
+
+func findHijackedWriters(writer http.ResponseWriter) hijackedWriters {
+
+	wrappers := hijackedWriters{}
+
+	for {
+		switch w := writer.(type) {
+		case *headResponseWriter:
+			wrappers.head = true
+		case *responseWriterRecorder:
+			wrappers.recorder = w
+		case *gzipResponseWriter:
+			wrappers.compressor = w
+		case *writeErrorRecorder:
+			wrappers.writeErrors = w
+		}
+
+		unwrapper, ok := writer.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			return wrappers
+		}
+		writer = unwrapper.Unwrap()
+	}
+}
+
+// Listener handing a hijacked connection back to the http server, closed along with the connection
+type handedBackListener struct {
+	conns  chan *handedBackConn
+	closed chan struct{}
+	once   sync.Once
+	addr   net.Addr
+}
+
+func newHandedBackListener(conn net.Conn, reader *bufio.Reader) *handedBackListener {
+
+	l := &handedBackListener{conns: make(chan *handedBackConn, 1), closed: make(chan struct{}), addr: conn.LocalAddr()}
+	l.conns <- &handedBackConn{Conn: conn, reader: reader, listener: l}
+
+	return l
+}
+
+func (l *handedBackListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+// Also closes the connection if the server did not accept it, e.g. as it is shutting down
+func (l *handedBackListener) Close() error {
+	l.once.Do(func() {
+		close(l.closed)
+		select {
+		case conn := <-l.conns:
+			conn.Conn.Close()
+		default:
+		}
+	})
+	return nil
+}
+
+func (l *handedBackListener) Addr() net.Addr {
+	return l.addr
+}
+
+type handedBackConn struct {
+	net.Conn
+	reader   *bufio.Reader // holds what the client already sent, e.g. a pipelined request
+	listener *handedBackListener
+}
+
+func (c *handedBackConn) Read(p []byte) (int, error) {
+	return c.reader.Read(p)
+}
+
+func (c *handedBackConn) Close() error {
+	c.listener.Close()
+	return c.Conn.Close()
+}
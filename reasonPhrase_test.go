@@ -0,0 +1,189 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Custom reason phrase tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReasonPhrase(t *testing.T) {
+
+	var mutex sync.Mutex
+	var entries []*RequestLogEntry
+
+	s := NewServer(`/`, `:0`)
+	s.EnableAutomaticHead(true)
+	s.EnableResponseCompression(true)
+	s.SetMetricsObserver(func(entry *RequestLogEntry) {
+		mutex.Lock()
+		entries = append(entries, entry)
+		mutex.Unlock()
+	})
+	s.NewEndpoint(`/rejected`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+		return ResourceHandlerResult{HttpStatus: http.StatusUnprocessableEntity, ReasonPhrase: `Validation Failed`, Body: bytes.NewBufferString(strings.Repeat(`bad `, 100))}
+	})})
+
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		request    string
+		statusLine string
+		gzipped    bool
+		body       string
+	}{
+		{`HTTP/1.1`, "GET /rejected HTTP/1.1\r\nHost: test\r\nAccept: */*\r\nConnection: close\r\n\r\n", "HTTP/1.1 422 Validation Failed\r\n", false, strings.Repeat(`bad `, 100)},
+		{`HTTP/1.0`, "GET /rejected HTTP/1.0\r\nHost: test\r\nAccept: */*\r\n\r\n", "HTTP/1.0 422 Validation Failed\r\n", false, strings.Repeat(`bad `, 100)},
+		{`HEAD`, "HEAD /rejected HTTP/1.1\r\nHost: test\r\nAccept: */*\r\nConnection: close\r\n\r\n", "HTTP/1.1 422 Validation Failed\r\n", false, ``},
+		{`compression`, "GET /rejected HTTP/1.1\r\nHost: test\r\nAccept: */*\r\nAccept-Encoding: gzip\r\nConnection: close\r\n\r\n", "HTTP/1.1 422 Validation Failed\r\n", true, strings.Repeat(`bad `, 100)},
+	}
+
+	for i, test := range tests {
+		conn, err := net.Dial(`tcp`, server.Listener.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		conn.Write([]byte(test.request))
+		response, _ := io.ReadAll(conn)
+		conn.Close()
+
+		parts := strings.SplitN(string(response), "\r\n\r\n", 2)
+		if !strings.HasPrefix(parts[0]+"\r\n", test.statusLine) {
+			t.Errorf(`%s : unexpected status line in %q`, test.name, parts[0])
+		}
+		if len(parts) != 2 {
+			t.Errorf(`%s : unexpected response %q`, test.name, response)
+			continue
+		}
+
+		body := parts[1]
+		bodyWritten := int64(len(body))
+		if test.gzipped {
+			if !strings.Contains(parts[0], "Content-Encoding: gzip\r\n") {
+				t.Errorf(`%s : not compressed %q`, test.name, parts[0])
+			}
+			if reader, err := gzip.NewReader(strings.NewReader(body)); err == nil {
+				decompressed, _ := io.ReadAll(reader)
+				body = string(decompressed)
+			}
+		}
+		if body != test.body {
+			t.Errorf(`%s : unexpected body %q`, test.name, body)
+		}
+		if test.name != `HEAD` && !strings.Contains(parts[0], "Content-Length: "+strconv.FormatInt(bodyWritten, 10)+"\r\n") {
+			t.Errorf(`%s : unexpected headers %q`, test.name, parts[0])
+		}
+
+		// Observed once ServeHTTP returns, which may be after the connection is closed
+		var entry *RequestLogEntry
+		for entry == nil {
+			mutex.Lock()
+			if len(entries) > i {
+				entry = entries[i]
+			}
+			mutex.Unlock()
+		}
+		if entry.HttpStatus != http.StatusUnprocessableEntity || entry.ResponseBytes != bodyWritten {
+			t.Errorf(`%s : metrics got %d and %d bytes, expected %d bytes`, test.name, entry.HttpStatus, entry.ResponseBytes, bodyWritten)
+		}
+	}
+}
+
+func TestReasonPhraseKeepsConnectionAlive(t *testing.T) {
+
+	s := NewServer(`/`, `:0`)
+	s.NewEndpoint(`/rejected`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+		return ResourceHandlerResult{HttpStatus: http.StatusUnprocessableEntity, ReasonPhrase: `Validation Failed`, Body: bytes.NewBufferString(`bad`)}
+	})})
+	s.NewEndpoint(`/users`, textResourceHandler(`GET`, `users`))
+
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	tests := []struct {
+		request string
+		status  string
+		body    string
+		close   bool
+	}{
+		{"GET /rejected HTTP/1.1\r\nHost: test\r\nAccept: */*\r\n\r\n", `422 Validation Failed`, `bad`, false},
+		{"GET /users HTTP/1.1\r\nHost: test\r\nAccept: */*\r\n\r\n", `200 OK`, `users`, false},
+		{"GET /rejected HTTP/1.1\r\nHost: test\r\nAccept: */*\r\n\r\n", `422 Validation Failed`, `bad`, false},
+		{"GET /rejected HTTP/1.1\r\nHost: test\r\nAccept: */*\r\nConnection: close\r\n\r\n", `422 Validation Failed`, `bad`, true},
+	}
+
+	// Every request on the same connection, the last one closing it
+	conn, err := net.Dial(`tcp`, server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	for i, test := range tests {
+		conn.SetDeadline(time.Now().Add(5 * time.Second))
+		conn.Write([]byte(test.request))
+		response, err := http.ReadResponse(reader, nil)
+		if err != nil {
+			t.Fatalf(`request %d : %s`, i, err.Error())
+		}
+		body, _ := io.ReadAll(response.Body)
+		response.Body.Close()
+		if response.Status != test.status || string(body) != test.body || response.Close != test.close {
+			t.Errorf(`request %d : got %q %q closing %t, expected %q %q closing %t`, i, response.Status, body, response.Close, test.status, test.body, test.close)
+		}
+	}
+
+	if _, err := reader.ReadByte(); err != io.EOF {
+		t.Errorf(`connection still open after Connection: close, got %v`, err)
+	}
+}
+
+func TestReasonPhraseWithoutHijacking(t *testing.T) {
+
+	s := NewServer(`/`, `:0`)
+	s.NewEndpoint(`/rejected`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+		return ResourceHandlerResult{HttpStatus: http.StatusUnprocessableEntity, ReasonPhrase: `Validation Failed`, Body: bytes.NewBufferString(`bad`)}
+	})})
+
+	// The recorder can not be hijacked : the standard status text is used
+	recorder := serveTestRequest(s, `GET`, `/rejected`, nil, nil)
+	if recorder.Code != http.StatusUnprocessableEntity || recorder.Body.String() != `bad` {
+		t.Errorf(`got %d %q`, recorder.Code, recorder.Body.String())
+	}
+}
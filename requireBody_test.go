@@ -0,0 +1,77 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Required request body tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRequireBodyForMethods(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		method      string
+		requirement BodyRequirement
+		body        string
+		status      int
+	}{
+		{`post with a body`, `POST`, BodyRequirementDefault, `{"name":"ada"}`, http.StatusOK},
+		{`post without a body`, `POST`, BodyRequirementDefault, ``, http.StatusBadRequest},
+		{`put without a body`, `PUT`, BodyRequirementDefault, ``, http.StatusBadRequest},
+		{`delete without a body`, `DELETE`, BodyRequirementDefault, ``, http.StatusOK},
+		{`optional post without a body`, `POST`, BodyOptional, ``, http.StatusOK},
+		{`required delete without a body`, `DELETE`, BodyRequired, ``, http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		s := NewServer(`/`, `:0`)
+		s.SetRequireBodyForMethods(`POST`, `PUT`)
+		s.NewEndpoint(`/users`, ResourceHandler{Method: test.method, ContentTypeIn: []string{`application/json`}, ContentTypeOut: []string{`text/plain`}, BodyRequirement: test.requirement, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(`done`)}
+		})})
+
+		recorder := serveTestRequest(s, test.method, `/users`, strings.NewReader(test.body), map[string]string{`Content-Type`: `application/json`})
+		if recorder.Code != test.status {
+			t.Errorf(`%s : got %d, expected %d`, test.name, recorder.Code, test.status)
+		}
+	}
+}
+
+func TestBodyNotRequiredByDefault(t *testing.T) {
+
+	s := NewServer(`/`, `:0`)
+	s.NewEndpoint(`/users`, ResourceHandler{Method: `POST`, ContentTypeIn: []string{`application/json`}, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+		return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(`done`)}
+	})})
+
+	recorder := serveTestRequest(s, `POST`, `/users`, strings.NewReader(``), map[string]string{`Content-Type`: `application/json`})
+	if recorder.Code != http.StatusOK {
+		t.Errorf(`got %d, expected %d`, recorder.Code, http.StatusOK)
+	}
+}
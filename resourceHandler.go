@@ -27,7 +27,19 @@ package gorip
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type ResourceHandlerImplementation interface {
@@ -35,31 +47,460 @@ type ResourceHandlerImplementation interface {
 }
 
 type ResourceHandler struct {
-	Method          string
-	ContentTypeIn   []string
-	ContentTypeOut  []string
-	QueryParameters map[string]QueryParameter
-	Implementation  ResourceHandlerImplementation
-	Documentation   *ResourceHandlerDocumentation
+	Method                string
+	ContentTypeIn         []string
+	ContentTypeOut        []string
+	QueryParameters       map[string]QueryParameter
+	HeaderParameters      map[string]HeaderParameter
+	Implementation        ResourceHandlerImplementation
+	Documentation         *ResourceHandlerDocumentation
+	AcceptAnyBody         bool                 // raw body is passed through whatever its Content-Type, e.g. for signature verification
+	DefaultContentTypeOut string               // one of ContentTypeOut, produced when any content type is accepted
+	StreamBody            bool                 // body is left unread for ResourceHandlerContext.MultipartReader instead of being buffered, the max body size still applies while reading
+	SparseFieldsets       bool                 // a fields query parameter, e.g. ?fields=id,name, restricts JSON results to the given top-level keys
+	ResponseContentTypes  []StatusContentTypes // documented content types per status range, e.g. problem+json for errors
+	AllowDryRun           bool                 // ?dryRun=true or Prefer: handling=validate runs the validations but not Execute, see DryRunner
+	CacheControl          *CacheControl        // Cache-Control header of successful responses, unless the result sets one
+	DecodeErrorStatus     int                  // status of a *DecodeError returned by the handler, 400 when 0, e.g. 422
+	BodyRequirement       BodyRequirement      // whether a request must carry a body, overriding Server.SetRequireBodyForMethods
+}
+
+// Methods a resource handler may declare, matched case sensitively against the request method
+var resourceHandlerMethods = []string{`GET`, `HEAD`, `POST`, `PUT`, `PATCH`, `DELETE`, `OPTIONS`}
+
+func isResourceHandlerMethod(method string) bool {
+	for _, m := range resourceHandlerMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// Whether a resource handler consuming a body requires one
+type BodyRequirement int
+
+const (
+	BodyRequirementDefault BodyRequirement = iota // required for the methods given to Server.SetRequireBodyForMethods
+	BodyRequired
+	BodyOptional
+)
+
+// True if a request to the resource handler must carry a body, given the methods requiring one by default
+func (r *ResourceHandler) requiresBody(defaultMethods []string) bool {
+
+	if len(r.ContentTypeIn) == 0 && !r.AcceptAnyBody {
+		return false
+	}
+
+	switch r.BodyRequirement {
+	case BodyRequired:
+		return true
+	case BodyOptional:
+		return false
+	}
+
+	for _, method := range defaultMethods {
+		if method == r.Method {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Content types produced for statuses from MinStatus to MaxStatus, both inclusive
+type StatusContentTypes struct {
+	MinStatus    int
+	MaxStatus    int
+	ContentTypes []string
+}
+
+func (s StatusContentTypes) String() string {
+	if s.MinStatus == s.MaxStatus {
+		return strconv.Itoa(s.MinStatus)
+	}
+	return fmt.Sprintf("%d-%d", s.MinStatus, s.MaxStatus)
+}
+
+// True if the content type is declared for the status, or if no range declares the status
+func (r *ResourceHandler) IsDeclaredResponseContentType(httpStatus int, contentType string) bool {
+
+	declared := true
+
+	for _, statusContentTypes := range r.ResponseContentTypes {
+		if httpStatus < statusContentTypes.MinStatus || httpStatus > statusContentTypes.MaxStatus {
+			continue
+		}
+		for _, declaredContentType := range statusContentTypes.ContentTypes {
+			if declaredContentType == contentType {
+				return true
+			}
+		}
+		declared = false
+	}
+
+	return declared
+}
+
+// ContentTypeOut with DefaultContentTypeOut moved first, if it is one of them
+func (r *ResourceHandler) GetContentTypeOutDefaultFirst() []string {
+
+	if r.DefaultContentTypeOut == `` {
+		return r.ContentTypeOut
+	}
+
+	ordered := []string{}
+	for _, contentType := range r.ContentTypeOut {
+		if contentType == r.DefaultContentTypeOut {
+			ordered = append([]string{contentType}, ordered...)
+		} else {
+			ordered = append(ordered, contentType)
+		}
+	}
+
+	return ordered
 }
 
 type ResourceHandlerContext struct {
-	RouteVariables  map[string]string
+	RouteVariables  map[string]string // nil when the matched route has no variables
 	QueryParameters map[string]string
 	ContentTypeIn   *string
 	ContentTypeOut  *string
-	Body            *bytes.Buffer
+	Body            *bytes.Buffer // empty when the body was spilled to a temporary file, see Server.SetBodySpillThreshold
+	BodyReader      io.ReadSeeker // the whole body, in memory or spilled to a temporary file
+	BodyTruncated   bool          // the body was cut to the maximum size, see Server.SetMaxBodySize
 	Header          http.Header
 	RequestId       *string
+	OriginalPath    string // URL path as requested, before any rewriting
+	Method          string
+	Route           string               // route template of the matched endpoint, e.g. /users/{id}
+	Version         string               // API version of the matched endpoint, empty if not versioned, see Server.NewVersionedEndpoint
+	Context         context.Context      // request context, canceled when the client goes away or the request deadline is reached
+	DryRun          bool                 // validation only was requested, see ResourceHandler.AllowDryRun
+	TLS             *tls.ConnectionState // nil for plain HTTP requests
+
+	catchAllIdentifier  string                    // route variable holding the catch-all tail, if the matched route has one
+	routeVariableValues map[string]interface{}    // typed route variables, see RouteVariableParser
+	responseWriter      http.ResponseWriter       // connection of the response, see ExtendWriteDeadline
+	codecs              map[string]Codec          // codecs of the server, see Decode
+	queryParameters     map[string]QueryParameter // declared query parameters, see BindQuery
+	values              map[string]interface{}    // request scoped values, see Set
+	bodyStream          io.Reader                 // unread request body, see ResourceHandler.StreamBody
+	proto               string                    // protocol of the request, e.g. HTTP/1.0, see ResourceHandlerResult.ReasonPhrase
+	renderedResult      *ResourceHandlerResult    // result of the resource handler as written, see Server.SetAuditHook
+	idempotency         *idempotentRequest        // nil unless the request carries an idempotency key, see Server.EnableIdempotency
+
+	contentLengthHintAllowed bool   // automatic HEAD that may be answered from a ContentLengthHinter
+	hintedContentLength      *int64 // set once it was
+}
+
+// Decodes the body into v with the codec registered for the request content type,
+// so that a handler consuming several content types, e.g. JSON and forms, gets the same value
+// A malformed body is reported as a *DecodeError
+func (c *ResourceHandlerContext) Decode(v interface{}) error {
+
+	codecs := c.codecs
+	if codecs == nil {
+		codecs = newDefaultCodecs()
+	}
+
+	codec, ok := findCodec(codecs, c.RequestContentType())
+	if !ok {
+		return errors.New(fmt.Sprintf(`No codec registered for content type %s`, c.RequestContentType()))
+	}
+
+	if c.BodyReader == nil {
+		return errors.New(`Request has no body`)
+	}
+
+	_, err := c.BodyReader.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	body, err := ioutil.ReadAll(c.BodyReader)
+	if err != nil {
+		return err
+	}
+
+	err = codec.Unmarshal(body, v)
+	if err != nil {
+		return &DecodeError{ContentType: c.RequestContentType(), Err: err}
+	}
+
+	return nil
+}
+
+// Pushes the write deadline of the connection d from now, e.g. to keep a long stream alive under the server WriteTimeout
+// Returns an error when the connection does not support it
+func (c *ResourceHandlerContext) ExtendWriteDeadline(d time.Duration) error {
+
+	if c.responseWriter == nil {
+		return errors.New(`No connection to extend the write deadline of`)
+	}
+
+	return http.NewResponseController(c.responseWriter).SetWriteDeadline(time.Now().Add(d))
+}
+
+// Certificate the client authenticated with over mutual TLS, nil if none
+func (c *ResourceHandlerContext) ClientCertificate() *x509.Certificate {
+
+	if c.TLS == nil || len(c.TLS.PeerCertificates) == 0 {
+		return nil
+	}
+
+	return c.TLS.PeerCertificates[0]
+}
+
+// Negotiated response content type, empty if none
+func (c *ResourceHandlerContext) ResponseContentType() string {
+	if c.ContentTypeOut == nil {
+		return ``
+	}
+	return *c.ContentTypeOut
+}
+
+// Version of the negotiated vendor media type, e.g. 2 for application/vnd.api.v2+json or application/vnd.api+json; version=2,
+// so that one handler can produce a different shape per version ; empty if the content type is not versioned
+func (c *ResourceHandlerContext) NegotiatedVersion() string {
+
+	mediaType, params, err := mime.ParseMediaType(c.ResponseContentType())
+	if err != nil {
+		return ``
+	}
+
+	if params[`version`] != `` {
+		return params[`version`]
+	}
+
+	subtype := mediaType[strings.Index(mediaType, `/`)+1:]
+	if plus := strings.LastIndex(subtype, `+`); plus >= 0 {
+		subtype = subtype[:plus]
+	}
+
+	facets := strings.Split(subtype, `.`)
+	for i := len(facets) - 1; i > 0; i-- {
+		if isVersionFacet(facets[i]) {
+			return facets[i][1:]
+		}
+	}
+
+	return ``
+}
+
+// True for a facet such as v2, a v followed by digits
+func isVersionFacet(facet string) bool {
+
+	if len(facet) < 2 || facet[0] != 'v' {
+		return false
+	}
+
+	for _, r := range facet[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Matched request content type, empty if the request has no body
+func (c *ResourceHandlerContext) RequestContentType() string {
+	if c.ContentTypeIn == nil {
+		return ``
+	}
+	return *c.ContentTypeIn
+}
+
+// Returns the typed value of a route variable whose type implements RouteVariableParser
+func (c *ResourceHandlerContext) RouteVariableValue(name string) (interface{}, bool) {
+	value, ok := c.routeVariableValues[name]
+	return value, ok
+}
+
+// Returns the path tail captured by the catch-all route variable, if the matched route has one
+func (c *ResourceHandlerContext) CatchAll() (string, bool) {
+
+	if c.catchAllIdentifier == `` {
+		return ``, false
+	}
+
+	return c.RouteVariables[c.catchAllIdentifier], true
+}
+
+// Returns the supported charset the Accept-Charset header prefers, by q-value then by order of supported
+// The first supported charset is the default, when the header is missing or accepts none of them
+func (c *ResourceHandlerContext) NegotiateCharset(supported []string) string {
+
+	if len(supported) == 0 {
+		return ``
+	}
+
+	acceptCharset := c.Header.Get(`Accept-Charset`)
+	if acceptCharset == `` {
+		return supported[0]
+	}
+
+	// q-value of each charset of the header, * standing for any other one
+	priorities := make(map[string]float64)
+	for _, element := range strings.Split(acceptCharset, `,`) {
+
+		parts := strings.Split(element, `;`)
+		charset := strings.ToLower(strings.TrimSpace(parts[0]))
+		if charset == `` {
+			continue
+		}
+
+		priority := 1.0
+		for _, param := range parts[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, `q=`) {
+				q, err := strconv.ParseFloat(strings.TrimPrefix(param, `q=`), 64)
+				if err == nil {
+					priority = q
+				}
+			}
+		}
+
+		priorities[charset] = priority
+	}
+
+	best := ``
+	bestPriority := 0.0
+	for _, charset := range supported {
+
+		priority, ok := priorities[strings.ToLower(charset)]
+		if !ok {
+			priority, ok = priorities[`*`]
+		}
+
+		if ok && priority > bestPriority {
+			best = charset
+			bestPriority = priority
+		}
+	}
+
+	if best == `` {
+		return supported[0]
+	}
+
+	return best
+}
+
+// Reads a multipart body part by part from BodyReader, a body spilled to a temporary file is never loaded in memory
+// With ResourceHandler.StreamBody the parts are read straight from the connection, only once,
+// reading past the max body size then fails with an error the handler returns to get a 413
+func (c *ResourceHandlerContext) MultipartReader() (*multipart.Reader, error) {
+
+	mediaType, params, err := mime.ParseMediaType(c.Header.Get(`Content-Type`))
+	if err != nil || !strings.HasPrefix(mediaType, `multipart/`) {
+		return nil, errors.New(fmt.Sprintf(`Request body is not multipart : %s`, c.Header.Get(`Content-Type`)))
+	}
+
+	if params[`boundary`] == `` {
+		return nil, errors.New(`Multipart request body has no boundary`)
+	}
+
+	if c.bodyStream != nil {
+		return multipart.NewReader(c.bodyStream, params[`boundary`]), nil
+	}
+
+	if c.BodyReader == nil {
+		return nil, errors.New(`Request has no body`)
+	}
+
+	_, err = c.BodyReader.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+
+	return multipart.NewReader(c.BodyReader, params[`boundary`]), nil
 }
 
 type ResourceHandlerResult struct {
-	HttpStatus int
-	Body       *bytes.Buffer
+	HttpStatus  int
+	Body        *bytes.Buffer
+	Payload     interface{} // serialized with the codec of the negotiated content type when Body is nil
+	ContentType string      // overrides the negotiated content type when set
+
+	ReasonPhrase string // replaces the standard status text on plain HTTP/1.x connections, not on HTTP/2 nor TLS ones
+
+	Raw bool // Body is final, e.g. pre-rendered or cached, and written verbatim : no codec, transformer nor field selection
+
+	Header http.Header // additional response headers
+
+	Stream   func(stream *ResponseStream) error // when set, writes the body progressively instead of Body
+	Trailers []string                           // trailer names set by Stream once the body is written
+}
+
+// Result carrying a Go value, serialized by the server using the codec of the negotiated content type
+func NewResult(status int, payload interface{}) ResourceHandlerResult {
+	return ResourceHandlerResult{HttpStatus: status, Payload: payload}
+}
+
+// 202 result of an asynchronous operation, its Location header pointing to the status resource to poll
+func AcceptedResult(statusLocation string) ResourceHandlerResult {
+	return ResourceHandlerResult{HttpStatus: http.StatusAccepted}.withHeader(`Location`, statusLocation)
+}
+
+// Returns a copy of the result with a Retry-After header, in seconds from now
+func (r ResourceHandlerResult) WithRetryAfter(d time.Duration) ResourceHandlerResult {
+	return r.withHeader(`Retry-After`, FormatRetryAfter(d))
+}
+
+// Returns a copy of the result with a Retry-After header, as an HTTP date
+func (r ResourceHandlerResult) WithRetryAfterDate(t time.Time) ResourceHandlerResult {
+	return r.withHeader(`Retry-After`, FormatRetryAfterDate(t))
+}
+
+// Returns a copy of the result setting the cookie, each cookie being sent in its own Set-Cookie header
+func (r ResourceHandlerResult) WithCookie(cookie *http.Cookie) ResourceHandlerResult {
+
+	header := r.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	header.Add(`Set-Cookie`, cookie.String())
+	r.Header = header
+
+	return r
+}
+
+// Sets a header on a copy of the header, so that results sharing it are not affected
+func (r ResourceHandlerResult) withHeader(name string, value string) ResourceHandlerResult {
+
+	header := r.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	header.Set(name, value)
+	r.Header = header
+
+	return r
+}
+
+// Retry-After value as delta seconds, rounded up so that clients do not retry too early
+func FormatRetryAfter(d time.Duration) string {
+
+	if d < 0 {
+		d = 0
+	}
+
+	return strconv.FormatInt(int64((d+time.Second-1)/time.Second), 10)
+}
+
+// Retry-After value as an HTTP date
+func FormatRetryAfterDate(t time.Time) string {
+	return t.UTC().Format(http.TimeFormat)
 }
 
 type ResourceHandlerDocumentation struct {
 	TestURL         string
 	TestContentType string
 	AdditionalNotes string
+	Summary         string // one line, shown next to the method
+	Description     string
+	ExampleRequest  string // request body example, shown as is
+	ExampleResponse string // response body example, shown as is
 }
@@ -0,0 +1,246 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Resource handler context tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNegotiatedContentTypeHelpers(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		options  []TestContextOption
+		request  string
+		response string
+	}{
+		{`none`, nil, ``, ``},
+		{`request only`, []TestContextOption{WithContentTypeIn(`application/json`)}, `application/json`, ``},
+		{`response only`, []TestContextOption{WithContentTypeOut(`application/xml`)}, ``, `application/xml`},
+		{`both`, []TestContextOption{WithContentTypeIn(`application/x-www-form-urlencoded`), WithContentTypeOut(`text/html`)}, `application/x-www-form-urlencoded`, `text/html`},
+	}
+
+	for _, test := range tests {
+		context := NewTestContext(test.options...)
+		if context.RequestContentType() != test.request || context.ResponseContentType() != test.response {
+			t.Errorf(`%s : got %q and %q, expected %q and %q`, test.name, context.RequestContentType(), context.ResponseContentType(), test.request, test.response)
+		}
+	}
+}
+
+func TestNegotiateCharset(t *testing.T) {
+
+	supported := []string{`utf-8`, `iso-8859-1`, `utf-16`}
+
+	tests := []struct {
+		name          string
+		acceptCharset string
+		supported     []string
+		charset       string
+	}{
+		{`missing header`, ``, supported, `utf-8`},
+		{`single charset`, `iso-8859-1`, supported, `iso-8859-1`},
+		{`weighted`, `utf-8;q=0.5, iso-8859-1;q=0.8`, supported, `iso-8859-1`},
+		{`equal weights follow supported order`, `utf-16, iso-8859-1`, supported, `iso-8859-1`},
+		{`case insensitive`, `UTF-16`, supported, `utf-16`},
+		{`wildcard`, `*;q=0.3, utf-16;q=0.2`, supported, `utf-8`},
+		{`refused charset`, `utf-8;q=0, *;q=0.1`, supported, `iso-8859-1`},
+		{`none supported`, `koi8-r`, supported, `utf-8`},
+		{`nothing supported`, `utf-8`, nil, ``},
+	}
+
+	for _, test := range tests {
+		context := NewTestContext(WithHeader(`Accept-Charset`, test.acceptCharset))
+		if charset := context.NegotiateCharset(test.supported); charset != test.charset {
+			t.Errorf(`%s : got %q, expected %q`, test.name, charset, test.charset)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+
+	date := time.Date(2026, time.October, 16, 15, 4, 5, 0, time.FixedZone(`CEST`, 2*60*60))
+
+	tests := []struct {
+		name       string
+		result     ResourceHandlerResult
+		retryAfter string
+	}{
+		{`whole seconds`, NewResult(http.StatusTooManyRequests, nil).WithRetryAfter(30 * time.Second), `30`},
+		{`rounded up`, NewResult(http.StatusTooManyRequests, nil).WithRetryAfter(1500 * time.Millisecond), `2`},
+		{`zero`, NewResult(http.StatusTooManyRequests, nil).WithRetryAfter(0), `0`},
+		{`negative`, NewResult(http.StatusTooManyRequests, nil).WithRetryAfter(-time.Minute), `0`},
+		{`http date`, NewResult(http.StatusServiceUnavailable, nil).WithRetryAfterDate(date), `Fri, 16 Oct 2026 13:04:05 GMT`},
+	}
+
+	for _, test := range tests {
+		if retryAfter := test.result.Header.Get(`Retry-After`); retryAfter != test.retryAfter {
+			t.Errorf(`%s : got %q, expected %q`, test.name, retryAfter, test.retryAfter)
+		}
+	}
+
+	// Results sharing a header are not affected
+	shared := ResourceHandlerResult{HttpStatus: http.StatusServiceUnavailable, Header: http.Header{`X-Reason`: {`deploy`}}}
+	shared.WithRetryAfter(time.Minute)
+	if shared.Header.Get(`Retry-After`) != `` {
+		t.Error(`the original result header was changed`)
+	}
+}
+
+func TestAcceptedResult(t *testing.T) {
+
+	s := NewServer(`/`, `:0`)
+	s.NewEndpoint(`/exports`, ResourceHandler{Method: `POST`, ContentTypeIn: []string{}, ContentTypeOut: []string{`application/json`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+		return AcceptedResult(`/exports/42/status`)
+	})})
+
+	recorder := serveTestRequest(s, `POST`, `/exports`, nil, nil)
+	if recorder.Code != http.StatusAccepted || recorder.Header().Get(`Location`) != `/exports/42/status` {
+		t.Errorf(`got %d with Location %q`, recorder.Code, recorder.Header().Get(`Location`))
+	}
+}
+
+// Self-signed client certificate for commonName
+func newClientTestCertificate(t *testing.T, commonName string) tls.Certificate {
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func TestClientCertificate(t *testing.T) {
+
+	tests := []struct {
+		name         string
+		certificates []tls.Certificate
+		subject      string
+	}{
+		{`with a client certificate`, []tls.Certificate{newClientTestCertificate(t, `billing-service`)}, `CN=billing-service`},
+		{`without a client certificate`, nil, `none`},
+	}
+
+	s := NewServer(`/`, `:0`)
+	s.NewEndpoint(`/whoami`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+		subject := `none`
+		if certificate := context.ClientCertificate(); certificate != nil {
+			subject = certificate.Subject.String()
+		}
+		return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(subject)}
+	})})
+
+	server := httptest.NewUnstartedServer(s)
+	server.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	for _, test := range tests {
+		client := server.Client()
+		client.Transport.(*http.Transport).TLSClientConfig.Certificates = test.certificates
+		client.Transport.(*http.Transport).CloseIdleConnections()
+
+		request, _ := http.NewRequest(`GET`, server.URL+`/whoami`, nil)
+		request.Header.Set(`Accept`, `text/plain`)
+		response, err := client.Do(request)
+		if err != nil {
+			t.Errorf(`%s : %s`, test.name, err.Error())
+			continue
+		}
+		body, _ := io.ReadAll(response.Body)
+		response.Body.Close()
+
+		if response.StatusCode != http.StatusOK || string(body) != test.subject {
+			t.Errorf(`%s : got %d %q, expected %q`, test.name, response.StatusCode, body, test.subject)
+		}
+	}
+
+	// Plain HTTP requests have no TLS state
+	if certificate := NewTestContext().ClientCertificate(); certificate != nil {
+		t.Error(`a client certificate without TLS`)
+	}
+}
+
+func TestResultCookies(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		cookies []*http.Cookie
+	}{
+		{`no cookie`, nil},
+		{`one cookie`, []*http.Cookie{{Name: `session`, Value: `abc`, HttpOnly: true}}},
+		{`two cookies`, []*http.Cookie{{Name: `session`, Value: `abc`, HttpOnly: true}, {Name: `theme`, Value: `dark`, Path: `/`, Expires: time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)}}},
+	}
+
+	for _, test := range tests {
+		s := NewServer(`/`, `:0`)
+		s.NewEndpoint(`/login`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			result := ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(`welcome`)}
+			for _, cookie := range test.cookies {
+				result = result.WithCookie(cookie)
+			}
+			return result
+		})})
+
+		recorder := serveTestRequest(s, `GET`, `/login`, nil, nil)
+
+		setCookies := recorder.Header().Values(`Set-Cookie`)
+		if len(setCookies) != len(test.cookies) {
+			t.Errorf(`%s : got %d Set-Cookie headers %q, expected %d`, test.name, len(setCookies), setCookies, len(test.cookies))
+			continue
+		}
+		for i, cookie := range recorder.Result().Cookies() {
+			if setCookies[i] != test.cookies[i].String() || cookie.Name != test.cookies[i].Name || cookie.Value != test.cookies[i].Value {
+				t.Errorf(`%s : got %q, expected %q`, test.name, setCookies[i], test.cookies[i].String())
+			}
+		}
+	}
+}
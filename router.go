@@ -29,12 +29,14 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 )
 
 const (
 	const_route_node_part         = ``
 	const_route_element_separator = `/`
+	const_route_catch_all_kind    = `*` // {identifier:*} captures the rest of the path, slashes included
 )
 
 type router struct {
@@ -58,6 +60,11 @@ func (r *router) NewEndpoint(endp *endpoint) error {
 		return errors.New(fmt.Sprintf(`A route must start with '%s'`, const_route_element_separator))
 	}
 
+	err := validateRoutePattern(routeString)
+	if err != nil {
+		return err
+	}
+
 	currentRouterNode := r.rootNode
 
 	splitRouteString := strings.Split(routeString, const_route_element_separator)
@@ -80,7 +87,7 @@ func (r *router) NewEndpoint(endp *endpoint) error {
 				if err != nil {
 					return err
 				} else {
-					if r.GetRouteVariableTypeByKind(rvKind) == nil {
+					if rvKind != const_route_catch_all_kind && r.GetRouteVariableTypeByKind(rvKind) == nil {
 						return errors.New(fmt.Sprintf("Given route uses an unknown route variable with kind '%s'", rvKind))
 					} else {
 						newChild = newRouterNodeVariable(r, v, rvIdentifier, rvKind)
@@ -141,39 +148,269 @@ func (r *router) GetRouteVariableTypeByKind(kind string) RouteVariableType {
 }
 
 // Find a matching route given url
+// At each level a static part is preferred to route variables, tried in part order so that the first matching one wins,
+// and a catch-all comes last ;
+// a branch that does not lead to an endpoint is left for the next candidate, e.g. with /users/me and /users/{id}/posts
+// registered, /users/me/posts is routed to the latter
+// Returns a nil node when no route matches, RouteVariableErrors when typed route variables reject parts,
+// any other error is a routing failure
+// The route variables map is only allocated when the route has variables, it is nil otherwise
 func (r *router) FindNodeByRoute(routeString string) (routerNode, map[string]string, error) {
 
-	routeVariableMap := make(map[string]string)
+	var routeVariableMap map[string]string
 
 	// Check root
 	if routeString == const_route_element_separator {
 		return r.rootNode, routeVariableMap, nil
 	}
 
+	// Parts are walked in place, without splitting the route ( ommit root ( part : ``, route : `/` ) )
+	separatorIndex := strings.Index(routeString, const_route_element_separator)
+	if separatorIndex < 0 {
+		return r.rootNode, routeVariableMap, nil
+	}
+	rest := routeString[separatorIndex+1:]
+
+	node := r.matchRoute(r.rootNode, rest, &routeVariableMap)
+	if node != nil {
+		return node, routeVariableMap, nil
+	}
+
+	// No endpoint matches : find out why, e.g. route variables rejecting parts
+	return r.findNodeByRouteGreedily(rest)
+}
+
+// Finds the endpoint node matching the rest of the route below the node, nil if there is none
+// The route variables of the branches left are removed from the map
+func (r *router) matchRoute(node routerNode, rest string, routeVariableMap *map[string]string) routerNode {
+
+	part := rest
+	remaining := ``
+	isLastPart := true
+	if separatorIndex := strings.Index(rest, const_route_element_separator); separatorIndex >= 0 {
+		part = rest[:separatorIndex]
+		remaining = rest[separatorIndex+1:]
+		isLastPart = false
+	}
+
+	if child, ok := node.GetChildren()[part].(*routerNodeInvariable); ok {
+		if found := r.matchRemainingRoute(child, remaining, isLastPart, routeVariableMap); found != nil {
+			return found
+		}
+	}
+
+	for _, variable := range node.GetVariableChildren() {
+		if !r.GetRouteVariableTypeByKind(variable.kind).Matches(part) {
+			continue
+		}
+		if *routeVariableMap == nil {
+			*routeVariableMap = make(map[string]string)
+		}
+		(*routeVariableMap)[variable.identifier] = part
+		if found := r.matchRemainingRoute(variable, remaining, isLastPart, routeVariableMap); found != nil {
+			return found
+		}
+		delete(*routeVariableMap, variable.identifier)
+	}
+
+	// Nothing else matches : a catch-all takes the rest of the path
+	if catchAll := node.GetCatchAllChild(); catchAll != nil && catchAll.GetEndpoint() != nil {
+		if *routeVariableMap == nil {
+			*routeVariableMap = make(map[string]string)
+		}
+		(*routeVariableMap)[catchAll.identifier] = rest
+		return catchAll
+	}
+
+	return nil
+}
+
+func (r *router) matchRemainingRoute(child routerNode, remaining string, isLastPart bool, routeVariableMap *map[string]string) routerNode {
+
+	if !isLastPart {
+		return r.matchRoute(child, remaining, routeVariableMap)
+	}
+
+	if child.GetEndpoint() == nil {
+		return nil
+	}
+
+	return child
+}
+
+// Walks the route without going back, static parts first, when no endpoint matches it
+// Returns RouteVariableErrors if route variables rejected parts, the node reached if any, which has no endpoint, nil otherwise
+func (r *router) findNodeByRouteGreedily(rest string) (routerNode, map[string]string, error) {
+
+	var routeVariableErrors RouteVariableErrors
+
+	currentRouterNode := r.rootNode
+
+	for {
+
+		v := rest
+		separatorIndex := strings.Index(rest, const_route_element_separator)
+		if separatorIndex >= 0 {
+			v = rest[:separatorIndex]
+		}
+
+		foundChild := currentRouterNode.GetChildByPart(v, true)
+
+		// A catch-all would have taken the rest of the path, unless route variables rejected parts before it
+		if foundChild == nil && currentRouterNode.GetCatchAllChild() != nil {
+			if len(routeVariableErrors) > 0 {
+				return nil, nil, routeVariableErrors
+			}
+			return nil, nil, nil
+		}
+
+		if foundChild != nil {
+			currentRouterNode = foundChild
+		} else {
+			// A route variable rejected the part : keep matching through it, so that all rejected parts are reported together
+			variable := currentRouterNode.GetFirstVariableChild()
+			if variable == nil {
+				if len(routeVariableErrors) > 0 {
+					return nil, nil, routeVariableErrors
+				}
+				// No route matches : not an error, the route simply does not exist
+				return nil, nil, nil
+			}
+			routeVariableErrors = append(routeVariableErrors, &RouteVariableError{Identifier: variable.identifier, Kind: variable.kind, Value: v})
+			currentRouterNode = variable
+		}
+
+		if separatorIndex < 0 {
+			break
+		}
+		rest = rest[separatorIndex+1:]
+	}
+
+	if len(routeVariableErrors) > 0 {
+		return nil, nil, routeVariableErrors
+	}
+
+	return currentRouterNode, nil, nil
+}
+
+// Find the node a route was registered on, given the route pattern itself
+func (r *router) FindNodeByPattern(routeString string) (routerNode, error) {
+
+	if !strings.HasPrefix(routeString, const_route_element_separator) {
+		return nil, errors.New(fmt.Sprintf(`A route must start with '%s'`, const_route_element_separator))
+	}
+
 	splitRouteString := strings.Split(routeString, const_route_element_separator)
 
 	// Start parsing parts ( ommit root ( part : ``, route : `/` ) with 1: )
 	currentRouterNode := r.rootNode
 	for _, v := range splitRouteString[1:] {
 
-		foundChild := currentRouterNode.GetChildByPart(v, true)
+		foundChild := currentRouterNode.GetChildByPart(v, false)
+		if foundChild == nil {
+			return nil, errors.New(fmt.Sprintf(`Route %s is not registered`, routeString))
+		}
 
-		if foundChild != nil {
+		currentRouterNode = foundChild
+	}
+
+	if currentRouterNode.GetEndpoint() == nil {
+		return nil, errors.New(fmt.Sprintf(`Route %s is not registered`, routeString))
+	}
+
+	return currentRouterNode, nil
+}
+
+// Lists the route variable identifiers declared by a registered route, in order
+func (r *router) GetRouteVariableIdentifiers(routeString string) ([]string, error) {
 
-			// Extract route variable if any
-			switch foundChild.(type) {
-			case *routerNodeVariable:
-				variable := foundChild.(*routerNodeVariable)
-				routeVariableMap[variable.identifier] = v
+	_, err := r.FindNodeByPattern(routeString)
+	if err != nil {
+		return nil, err
+	}
+
+	identifiers := []string{}
+
+	splitRouteString := strings.Split(routeString, const_route_element_separator)
+	for _, v := range splitRouteString[1:] {
+		if isRouteVariable(v) {
+			rvIdentifier, _, err := getRouteVariableParts(v)
+			if err != nil {
+				return nil, err
 			}
+			identifiers = append(identifiers, rvIdentifier)
+		}
+	}
 
-			currentRouterNode = foundChild
-		} else {
-			return nil, nil, errors.New(fmt.Sprintf(`Could not find a route given the part '%s'`, v))
+	return identifiers, nil
+}
+
+// Counts the endpoints and resource handlers registered in the router tree
+func (r *router) CountEndpoints() (int, int) {
+	return r.countEndpointsRecursive(r.rootNode)
+}
+
+func (r *router) countEndpointsRecursive(node routerNode) (int, int) {
+
+	endpoints := 0
+	resourceHandlers := 0
+
+	if node.GetEndpoint() != nil {
+		endpoints++
+		resourceHandlers += len(node.GetEndpoint().GetResourceHandlers())
+	}
+
+	for _, child := range node.GetChildren() {
+		e, rh := r.countEndpointsRecursive(child)
+		endpoints += e
+		resourceHandlers += rh
+	}
+
+	return endpoints, resourceHandlers
+}
+
+// Parses the route variables of a matched route with the types implementing RouteVariableParser
+// Every rejected value is reported in the returned RouteVariableErrors
+func (r *router) ParseRouteVariableValues(routeString string, routeVariables map[string]string) (map[string]interface{}, error) {
+
+	// Nothing to parse, nor to allocate, for a route without variables
+	if len(routeVariables) == 0 {
+		return nil, nil
+	}
+
+	values := make(map[string]interface{})
+	var routeVariableErrors RouteVariableErrors
+
+	splitRouteString := strings.Split(routeString, const_route_element_separator)
+	for _, v := range splitRouteString[1:] {
+
+		if !isRouteVariable(v) {
+			continue
+		}
+
+		rvIdentifier, rvKind, err := getRouteVariableParts(v)
+		if err != nil {
+			return nil, err
 		}
+
+		parser, ok := r.GetRouteVariableTypeByKind(rvKind).(RouteVariableParser)
+		if !ok {
+			continue
+		}
+
+		value, err := parser.Parse(routeVariables[rvIdentifier])
+		if err != nil {
+			routeVariableErrors = append(routeVariableErrors, &RouteVariableError{Identifier: rvIdentifier, Kind: rvKind, Value: routeVariables[rvIdentifier]})
+			continue
+		}
+		values[rvIdentifier] = value
+	}
+
+	if len(routeVariableErrors) > 0 {
+		return nil, routeVariableErrors
 	}
 
-	return currentRouterNode, routeVariableMap, nil
+	return values, nil
 }
 
 // Displays the resulting router tree in the log
@@ -205,6 +442,9 @@ type routerNode interface {
 	GetChildren() map[string]routerNode
 	AddChild(routerNode) error
 	GetChildByPart(part string, invariableMode bool) routerNode
+	GetFirstVariableChild() *routerNodeVariable
+	GetVariableChildren() []*routerNodeVariable
+	GetCatchAllChild() *routerNodeVariable
 	SetEndpoint(endp *endpoint)
 	GetEndpoint() *endpoint
 }
@@ -214,6 +454,10 @@ type routerNodeImplementation struct {
 	children     map[string]routerNode
 	parentRouter *router
 	endp         *endpoint
+
+	// Indexes of children, maintained by AddChild so that matching a request part does not walk all the children
+	variableChildren []*routerNodeVariable // sorted by part, catch-all excluded
+	catchAllChild    *routerNodeVariable
 }
 
 // Initialize the interface implementation of routerNode,
@@ -253,6 +497,20 @@ func (rni *routerNodeImplementation) AddChild(child routerNode) error {
 		rni.children[child.GetPart()] = child
 	}
 
+	switch child.(type) {
+	case *routerNodeVariable:
+		variable := child.(*routerNodeVariable)
+		if variable.IsCatchAll() {
+			rni.catchAllChild = variable
+		} else {
+			rni.variableChildren = append(rni.variableChildren, variable)
+			sort.Slice(rni.variableChildren, func(i, j int) bool {
+				return rni.variableChildren[i].GetPart() < rni.variableChildren[j].GetPart()
+			})
+		}
+	default:
+	}
+
 	return nil
 
 }
@@ -261,40 +519,44 @@ func (rni *routerNodeImplementation) GetRouter() *router {
 	return rni.parentRouter
 }
 
+// Static parts take precedence over route variables, which are tried in part order when several could match :
+// the first one, e.g. {a:kind} before {b:kind}, wins and no warning is logged for the others
 func (rni *routerNodeImplementation) GetChildByPart(part string, invariableMode bool) routerNode {
 
-	var nodeFound routerNode
+	// Check invariable ones, or the exact pattern part of a route variable when not in invariable mode
+	if child, ok := rni.children[part]; ok {
+		return child
+	}
 
 	if invariableMode {
-		// Check variable ones first
-		for k := range rni.children {
-			child := rni.children[k]
-
-			switch child.(type) {
-			case *routerNodeVariable:
-				variable := child.(*routerNodeVariable)
-				validator := child.GetRouter().GetRouteVariableTypeByKind(variable.kind)
-				if validator.Matches(part) {
-					if nodeFound != nil {
-						Flog(FLOG_TYPE_WARNING, fmt.Sprintf("Multiple routings for a given route"))
-					}
-					nodeFound = child
-				}
-			default:
+		for _, variable := range rni.variableChildren {
+			if variable.GetRouter().GetRouteVariableTypeByKind(variable.kind).Matches(part) {
+				return variable
 			}
 		}
 	}
 
-	// Check invariable ones
-	if _, ok := rni.children[part]; ok {
-		if nodeFound != nil {
-			Flog(FLOG_TYPE_WARNING, fmt.Sprintf("Multiple routings for a given route"))
-		}
-		return rni.children[part]
+	return nil
+}
+
+// Returns the route variable child with the smallest part, nil if there is none
+func (rni *routerNodeImplementation) GetFirstVariableChild() *routerNodeVariable {
+
+	if len(rni.variableChildren) == 0 {
+		return nil
 	}
 
-	return nodeFound
+	return rni.variableChildren[0]
+}
+
+// Returns the route variable children sorted by part, catch-all excluded
+func (rni *routerNodeImplementation) GetVariableChildren() []*routerNodeVariable {
+	return rni.variableChildren
+}
 
+// Returns the catch-all route variable child, nil if there is none
+func (rni *routerNodeImplementation) GetCatchAllChild() *routerNodeVariable {
+	return rni.catchAllChild
 }
 
 type routerNodeInvariable struct {
@@ -319,17 +581,62 @@ func newRouterNodeVariable(r *router, part string, identifier string, kind strin
 	return rnva
 }
 
+func (rnva *routerNodeVariable) IsCatchAll() bool {
+	return rnva.kind == const_route_catch_all_kind
+}
+
 type RouteVariableType interface {
 	Matches(string) bool
 }
 
+// Optionally implemented by a RouteVariableType to provide the typed value of a route variable,
+// e.g. an int or a time.Time, available to handlers through ResourceHandlerContext.RouteVariableValue
+type RouteVariableParser interface {
+	Parse(string) (interface{}, error)
+}
+
+// Returned by the router when a part does not match the kind of a typed route variable
+type RouteVariableError struct {
+	Identifier string
+	Kind       string
+	Value      string
+}
+
+func (e *RouteVariableError) Error() string {
+	return fmt.Sprintf(`Route variable '%s' must be of kind '%s', got '%s'`, e.Identifier, e.Kind, e.Value)
+}
+
+// All the route variables of a route rejecting their part, in route order
+type RouteVariableErrors []*RouteVariableError
+
+func (e RouteVariableErrors) Error() string {
+
+	messages := []string{}
+	for _, rvErr := range e {
+		messages = append(messages, rvErr.Error())
+	}
+
+	return strings.Join(messages, `, `)
+}
+
+// The rejected parts, in route order
+func (e RouteVariableErrors) values() []string {
+
+	values := []string{}
+	for _, rvErr := range e {
+		values = append(values, rvErr.Value)
+	}
+
+	return values
+}
+
 const (
 	const_regexp_route_variable_pattern       = "\\{(.*?)\\}"
-	const_regexp_route_variable_parts_pattern = "\\{([0-9a-zA-Z_]*)\\:([0-9a-zA-Z_]*)\\}"
+	const_regexp_route_variable_parts_pattern = "^\\{([0-9a-zA-Z_]+)\\:([0-9a-zA-Z_]+|\\*)\\}$"
 )
 
 var regexpRouteVariable *regexp.Regexp      // anything like {...}
-var regexpRouteVariableParts *regexp.Regexp // {identifier:kind} they both accepts alpha and numerals (a-z A-Z 0-9) with optional _
+var regexpRouteVariableParts *regexp.Regexp // {identifier:kind} they both accepts alpha and numerals (a-z A-Z 0-9) with optional _, kind may also be * ( catch-all )
 
 func isRouteVariable(part string) bool {
 	return regexpRouteVariable.MatchString(part)
@@ -349,6 +656,62 @@ func getRouteVariableParts(part string) (string, string, error) {
 
 }
 
+// Checks the syntax of a route before it is added to the router tree :
+// no empty segments, balanced braces and unique route variable identifiers
+func validateRoutePattern(routeString string) error {
+
+	// The root route is the only one allowed to end with a separator
+	if routeString == const_route_element_separator {
+		return nil
+	}
+
+	identifiers := make(map[string]bool)
+
+	splitRouteString := strings.Split(routeString, const_route_element_separator)
+
+	for i, v := range splitRouteString[1:] {
+
+		if v == `` {
+			return errors.New(fmt.Sprintf(`Route %s contains an empty segment`, routeString))
+		}
+
+		openCount := strings.Count(v, `{`)
+		closeCount := strings.Count(v, `}`)
+
+		if openCount == 0 && closeCount == 0 {
+			continue
+		}
+
+		if openCount != closeCount {
+			return errors.New(fmt.Sprintf(`Route %s has unbalanced braces in segment '%s'`, routeString, v))
+		}
+
+		if openCount != 1 || !strings.HasPrefix(v, `{`) || !strings.HasSuffix(v, `}`) {
+			return errors.New(fmt.Sprintf(`Route %s has a malformed route variable in segment '%s'`, routeString, v))
+		}
+
+		if v == `{}` {
+			return errors.New(fmt.Sprintf(`Route %s contains an empty route variable`, routeString))
+		}
+
+		rvIdentifier, rvKind, err := getRouteVariableParts(v)
+		if err != nil {
+			return err
+		}
+
+		if rvKind == const_route_catch_all_kind && i != len(splitRouteString)-2 {
+			return errors.New(fmt.Sprintf(`Route %s must end with its catch-all route variable '%s'`, routeString, rvIdentifier))
+		}
+
+		if identifiers[rvIdentifier] {
+			return errors.New(fmt.Sprintf(`Route %s declares route variable '%s' more than once`, routeString, rvIdentifier))
+		}
+		identifiers[rvIdentifier] = true
+	}
+
+	return nil
+}
+
 func init() {
 
 	var err error
@@ -0,0 +1,203 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Router tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestValidateRoutePattern(t *testing.T) {
+
+	tests := []struct {
+		route string
+		valid bool
+	}{
+		{`/`, true},
+		{`/users`, true},
+		{`/users/{id:any}`, true},
+		{`/users/{id:any}/posts/{post_id:any}`, true},
+		{`/files/{path:*}`, true},
+		{`/users/{id:any`, false},
+		{`/users/id:any}`, false},
+		{`/users/{}`, false},
+		{`/users//posts`, false},
+		{`/users/`, false},
+		{`/users/x{id:any}`, false},
+		{`/users/{id:any}/posts/{id:any}`, false},
+		{`/files/{path:*}/meta`, false},
+	}
+
+	for _, test := range tests {
+		err := validateRoutePattern(test.route)
+		if test.valid && err != nil {
+			t.Errorf(`%s : unexpected error %s`, test.route, err.Error())
+		}
+		if !test.valid && err == nil {
+			t.Errorf(`%s : expected an error`, test.route)
+		}
+	}
+}
+
+func TestNewEndpointRejectsMalformedRoutes(t *testing.T) {
+
+	r := newRouter()
+	r.NewRouteVariableType(`any`, anyRouteVariableType{})
+
+	for _, route := range []string{`/users/{id:any`, `/users/{}`, `/a/{x:any}/{x:any}`} {
+		if err := r.NewEndpoint(&endpoint{route: route}); err == nil {
+			t.Errorf(`%s : expected an error`, route)
+		}
+	}
+
+	if err := r.NewEndpoint(&endpoint{route: `/a/{x:any}/{y:any}`}); err != nil {
+		t.Errorf(`unexpected error %s`, err.Error())
+	}
+}
+
+// Router with 50 static and 50 variable routes
+func newBenchmarkRouter() *router {
+
+	r := newRouter()
+	r.NewRouteVariableType(`any`, anyRouteVariableType{})
+	for i := 0; i < 50; i++ {
+		r.NewEndpoint(&endpoint{route: fmt.Sprintf(`/static%d/items`, i)})
+		r.NewEndpoint(&endpoint{route: fmt.Sprintf(`/resources%d/{id:any}/children/{child_id:any}`, i)})
+	}
+
+	return r
+}
+
+func BenchmarkFindNodeByRoute(b *testing.B) {
+
+	r := newBenchmarkRouter()
+
+	benchmarks := []struct {
+		name string
+		path string
+	}{
+		{`static`, `/static25/items`},
+		{`variable`, `/resources25/42/children/7`},
+	}
+
+	for _, benchmark := range benchmarks {
+		b.Run(benchmark.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				r.FindNodeByRoute(benchmark.path)
+			}
+		})
+	}
+}
+
+func TestFindNodeByRouteWithoutVariablesDoesNotAllocate(t *testing.T) {
+
+	r := newBenchmarkRouter()
+
+	allocs := testing.AllocsPerRun(100, func() {
+		r.FindNodeByRoute(`/static25/items`)
+	})
+	if allocs != 0 {
+		t.Errorf(`%.0f allocations per lookup, expected none`, allocs)
+	}
+}
+
+// Route variables are the only allocations of a lookup : none for a route without variables
+func BenchmarkFindNodeByRouteWithoutVariables(b *testing.B) {
+
+	r := newBenchmarkRouter()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, routeVariables, _ := r.FindNodeByRoute(`/static25/items`)
+		if routeVariables != nil {
+			b.Fatal(`route variables map allocated for a route without variables`)
+		}
+	}
+}
+
+// Route of the endpoint a request path is routed to, empty if none
+func routeOf(t *testing.T, r *router, path string) string {
+
+	node, _, err := r.FindNodeByRoute(path)
+	if err != nil {
+		t.Fatalf(`%s : unexpected error %s`, path, err.Error())
+	}
+	if node == nil || node.GetEndpoint() == nil {
+		return ``
+	}
+
+	return node.GetEndpoint().GetRoute()
+}
+
+func TestFindNodeByRoutePrefersStaticParts(t *testing.T) {
+
+	r := newRouter()
+	r.NewRouteVariableType(`any`, anyRouteVariableType{})
+	for _, route := range []string{`/users/me`, `/users/{id:any}`, `/users/{id:any}/posts`, `/users/me/settings`, `/files/{path:*}`, `/files/readme`} {
+		if err := r.NewEndpoint(&endpoint{route: route}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		path  string
+		route string
+	}{
+		{`/users/me`, `/users/me`},
+		{`/users/42`, `/users/{id:any}`},
+		{`/users/me/settings`, `/users/me/settings`},
+		{`/users/42/posts`, `/users/{id:any}/posts`},
+		{`/users/me/posts`, `/users/{id:any}/posts`},
+		{`/users/me/unknown`, ``},
+		{`/files/readme`, `/files/readme`},
+		{`/files/readme/old`, `/files/{path:*}`},
+		{`/files/a/b`, `/files/{path:*}`},
+		{`/users`, ``},
+	}
+
+	for _, test := range tests {
+		if route := routeOf(t, r, test.path); route != test.route {
+			t.Errorf(`%s : routed to %q, expected %q`, test.path, route, test.route)
+		}
+	}
+}
+
+func TestFindNodeByRouteKeepsOnlyTheMatchedRouteVariables(t *testing.T) {
+
+	r := newRouter()
+	r.NewRouteVariableType(`any`, anyRouteVariableType{})
+	r.NewEndpoint(&endpoint{route: `/{a:any}/x`})
+	r.NewEndpoint(&endpoint{route: `/{b:any}/y`})
+
+	_, routeVariables, err := r.FindNodeByRoute(`/1/y`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(routeVariables) != 1 || routeVariables[`b`] != `1` {
+		t.Errorf(`unexpected route variables %v`, routeVariables)
+	}
+}
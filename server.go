@@ -27,14 +27,20 @@ package gorip
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
 	"github.com/sigu-399/goxibeta"
-	"io/ioutil"
+	"io"
 	"math/rand"
+	"net"
 	"net/http"
+	"net/url"
+	"os"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -42,39 +48,159 @@ type Server struct {
 	pattern string
 	address string
 	router  *router
+	mux     *http.ServeMux // owned by the server, several servers can live in one process
+
+	additionalAddresses []string
+
+	hostRouters []hostRouter
+
+	registrationErrors []error
 
 	documentationEndpointEnabled bool
 	documentationEndpointUrl     string
+	documentationAuth            func(r *http.Request) error
+	documentationChallenge       string
+
+	healthEndpointEnabled bool
+	healthEndpointUrl     string
+	draining              int32 // accessed atomically, see BeginDrain
+
+	maintenanceMode       int32 // accessed atomically, toggled while serving
+	maintenanceRetryAfter int64 // time.Duration, accessed atomically
+
+	trustedProxies []*net.IPNet
+	ipAllowlist    []*net.IPNet
+
+	notFoundTemplates []notFoundTemplate
+
+	problemJSONEnabled bool
+	missingAcceptAsAny bool
+
+	pathRewriter    func(path string) string
+	collapseSlashes bool
+	logMatchedRoute bool
+
+	automaticOptionsEnabled bool
+	automaticHeadEnabled    bool
+	optionsDiscoveryEnabled bool
 
 	debugEnableLogRequestDump       bool
+	debugRequestDumpBodyLimit       int64
+	debugResponseBodyLogLimit       int64
+	responseBodyRedactor            func(contentType string, body []byte) []byte
+	redactedHeaders                 []string
 	debugEnableLogRequestIdentifier bool
 	debugEnableLogRequestDuration   bool
+	debugEnableLogQueryParameters   bool
+	debugEnableCheckContentTypes    bool
+
+	slowRequestThreshold time.Duration
+	requestDeadline      time.Duration
+
+	metricsObserver   func(entry *RequestLogEntry)
+	writeErrorHandler func(ctx *ResourceHandlerContext, err error)
+	auditHook         func(ctx *ResourceHandlerContext, result *ResourceHandlerResult)
+
+	expectContinueHook func(ctx *ResourceHandlerContext) error
+
+	coerceEmptyBodyTo204 bool
+
+	maxQueryParameters int
+
+	bodySpillThreshold int64
+	maxBodySize        int64
+	maxBodyTruncate    bool
+
+	requestDecompressionEnabled bool
+	requestDecompressionMaxSize int64
+
+	responseCompressionEnabled bool
+	compressibleContentTypes   []string
 
 	internalResourceResultRenderer InternalResourceResultRenderer
+	responseTransformers           map[string]ResponseTransformer
+	codecs                         map[string]Codec
+
+	singleFlight *singleFlightGroup
+
+	cors *CORSOptions
+
+	autoETagEnabled bool
+
+	defaultRequestContentType string
+	requireBodyMethods        []string
+
+	contextProvider func(ctx *ResourceHandlerContext) error
+
+	writeSlots       chan struct{} // nil for no limit, see SetWriteConcurrencyLimit
+	writeSlotMaxWait time.Duration // zero to reject at once, see SetWriteConcurrencyMaxWait
+
+	connStateHook func(conn net.Conn, state http.ConnState)
+
+	nonceHeader string
+	nonceWindow time.Duration
+	nonceStore  NonceStore
+
+	idempotencyStore        IdempotencyStore
+	idempotencyTtl          time.Duration
+	idempotencyReservations *idempotencyReservations
+
+	onStartCallbacks    []func() error
+	onShutdownCallbacks []func()
+
+	httpServerMutex sync.Mutex
+	httpServers     []*http.Server
+	startTime       time.Time
+}
+
+// Read-only server introspection, see Server.Stats
+type ServerStats struct {
+	Endpoints        int
+	ResourceHandlers int
+	Uptime           time.Duration // zero until ListenAndServe is called
 }
 
 func NewServer(pattern string, address string) *Server {
 
 	Flog(FLOG_TYPE_INFO, "Creating goRip Server\n")
-	return &Server{pattern: pattern, address: address, router: newRouter(), internalResourceResultRenderer: &DefaultInternalResourceResultRenderer{}}
+	s := &Server{pattern: pattern, address: address, router: newRouter(), internalResourceResultRenderer: &DefaultInternalResourceResultRenderer{}}
+	s.responseTransformers = make(map[string]ResponseTransformer)
+	s.codecs = make(map[string]Codec)
+	s.registerDefaultCodecs()
+	s.debugRequestDumpBodyLimit = const_request_dump_default_body_limit
+
+	s.mux = http.NewServeMux()
+	s.mux.Handle(pattern, s)
+	return s
 
 }
 
 func (s *Server) NewEndpoint(route string, resourceHandlers ...ResourceHandler) error {
+	return s.newEndpointOnRouter(s.router, route, resourceHandlers...)
+}
+
+func (s *Server) newEndpointOnRouter(r *router, route string, resourceHandlers ...ResourceHandler) error {
+	return s.recordRegistrationError(s.addEndpointToRouter(r, route, resourceHandlers...))
+}
+
+func (s *Server) addEndpointToRouter(r *router, route string, resourceHandlers ...ResourceHandler) error {
 
 	endp := &endpoint{route: route}
 
 	if len(resourceHandlers) == 0 {
-		return errors.New("Endpoint must have at least one resource handler")
+		return errors.New(fmt.Sprintf("Endpoint %s must have at least one resource handler", route))
 	}
 
 	for _, res := range resourceHandlers {
+		if !isResourceHandlerMethod(res.Method) {
+			return errors.New(fmt.Sprintf("Resource handler of endpoint %s has an unrecognized method '%s', expected one of %s", route, res.Method, strings.Join(resourceHandlerMethods, `, `)))
+		}
 		endp.AddResource(res)
 	}
 
 	Flog(FLOG_TYPE_INFO, fmt.Sprintf("Adding endpoint : %s\n", TermColorEscape(endp.GetRoute(), TERM_COLOR_BLUE)))
 
-	return s.router.NewEndpoint(endp)
+	return r.NewEndpoint(endp)
 }
 
 func (s *Server) DebugEnableLogRequestDump(b bool) {
@@ -89,12 +215,123 @@ func (s *Server) DebugEnableLogRequestDuration(b bool) {
 	s.debugEnableLogRequestDuration = b
 }
 
+// Warns when a result is rendered with a content type not declared for its status in ResourceHandler.ResponseContentTypes
+func (s *Server) DebugEnableCheckResponseContentTypes(b bool) {
+	s.debugEnableCheckContentTypes = b
+}
+
+// Logs the declared, supplied and ignored query parameters of each request, e.g. to catch typos in clients
+func (s *Server) DebugEnableLogQueryParameters(b bool) {
+	s.debugEnableLogQueryParameters = b
+}
+
+// Registers a function called when a client connection changes state, see http.Server.ConnState,
+// e.g. to count the active connections ; must be set before serving
+func (s *Server) SetConnStateHook(hook func(conn net.Conn, state http.ConnState)) {
+	s.connStateHook = hook
+}
+
+// Registers a callback run by ListenAndServe before binding, startup is aborted if it returns an error
+// Callbacks are run in registration order
+func (s *Server) OnStart(callback func() error) {
+	s.onStartCallbacks = append(s.onStartCallbacks, callback)
+}
+
+func (s *Server) runOnStartCallbacks() error {
+
+	for _, callback := range s.onStartCallbacks {
+		err := callback()
+		if err != nil {
+			Flog(FLOG_TYPE_ERROR, fmt.Sprintf("Startup aborted, OnStart callback failed : %s", err.Error()))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Logs a warning for requests taking longer than d, zero disables the warning
+func (s *Server) SetSlowRequestThreshold(d time.Duration) {
+	s.slowRequestThreshold = d
+}
+
 func (s *Server) ListenAndServe() error {
 
-	Flog(FLOG_TYPE_ACTION, fmt.Sprintf("goRip is Ready, listening to %s\n", TermColorEscape(s.address, TERM_COLOR_BLUE)))
+	err := s.runOnStartCallbacks()
+	if err != nil {
+		return err
+	}
+
+	addresses := append([]string{s.address}, s.additionalAddresses...)
+
+	for _, address := range addresses {
+		Flog(FLOG_TYPE_ACTION, fmt.Sprintf("goRip is Ready, listening to %s\n", TermColorEscape(address, TERM_COLOR_BLUE)))
+	}
+
+	httpServers := []*http.Server{}
+	for _, address := range addresses {
+		httpServers = append(httpServers, &http.Server{Addr: address, Handler: s.rootHandler(), ConnState: s.connStateHook})
+	}
+
+	s.httpServerMutex.Lock()
+	s.httpServers = append(s.httpServers, httpServers...)
+	s.startTime = time.Now()
+	s.httpServerMutex.Unlock()
+
+	// All listeners run concurrently, the first one to stop ends ListenAndServe
+	errs := make(chan error, len(httpServers))
+	for _, httpServer := range httpServers {
+		go func(httpServer *http.Server) {
+			errs <- httpServer.ListenAndServe()
+		}(httpServer)
+	}
+
+	err = <-errs
+
+	// A listener failed : do not keep serving on the other ones
+	if err != http.ErrServerClosed {
+		for _, httpServer := range httpServers {
+			httpServer.Close()
+		}
+	}
+
+	return err
+}
 
-	http.Handle(s.pattern, s)
-	return http.ListenAndServe(s.address, nil)
+// Adds an address ListenAndServe listens to, in addition to the server address
+func (s *Server) AddListener(address string) {
+	s.additionalAddresses = append(s.additionalAddresses, address)
+}
+
+// Registers a callback run by Shutdown once connections are drained, for closing pools or flushing logs
+// Callbacks are run in registration order
+func (s *Server) OnShutdown(callback func()) {
+	s.onShutdownCallbacks = append(s.onShutdownCallbacks, callback)
+}
+
+// Gracefully stops the server : stops listening, waits for active connections to drain then runs the OnShutdown callbacks
+func (s *Server) Shutdown(ctx context.Context) error {
+
+	Flog(FLOG_TYPE_ACTION, "goRip is shutting down\n")
+
+	s.httpServerMutex.Lock()
+	httpServers := s.httpServers
+	s.httpServerMutex.Unlock()
+
+	var err error
+	for _, httpServer := range httpServers {
+		shutdownErr := httpServer.Shutdown(ctx)
+		if shutdownErr != nil {
+			Flog(FLOG_TYPE_ERROR, fmt.Sprintf("Error while draining connections on %s : %s", httpServer.Addr, shutdownErr.Error()))
+			err = shutdownErr
+		}
+	}
+
+	for _, callback := range s.onShutdownCallbacks {
+		callback()
+	}
+
+	return err
 }
 
 func (s *Server) DebugPrintRouterTree() {
@@ -114,15 +351,71 @@ func (s *Server) EnableDocumentationEndpoint(url string) {
 
 }
 
+// Guards the documentation endpoint : auth returns nil to let the request through, or else the error to deny it with,
+// an *HTTPError giving the status, e.g. 401 for missing or unrecognized credentials and 403 for credentials not allowed
+// to read it, any other error denying it with a 403 ; 401 responses carry the challenge as WWW-Authenticate header,
+// e.g. Basic realm="documentation", which must be given if auth may deny with a 401
+func (s *Server) SetDocumentationAuth(auth func(r *http.Request) error, challenge string) {
+	s.documentationAuth = auth
+	s.documentationChallenge = challenge
+}
+
+// Replays the first response of POST and PATCH requests carrying the same Idempotency-Key header and credentials within ttl,
+// headers included ; a duplicate sent while the first request is executed is answered 409
+// Responses are replayed in place of the implementation, once the context provider, the nonce check and the endpoint middlewares ran
+// A nil store defaults to an in memory store
+func (s *Server) EnableIdempotency(ttl time.Duration, store IdempotencyStore) {
+
+	if store == nil {
+		store = NewMemoryIdempotencyStore()
+	}
+
+	s.idempotencyStore = store
+	s.idempotencyTtl = ttl
+	s.idempotencyReservations = newIdempotencyReservations()
+}
+
+// Gives every request context a deadline, so that calls made by resource handlers time out, 0 disables it
+func (s *Server) SetRequestDeadline(d time.Duration) {
+	s.requestDeadline = d
+}
+
+// Answers OPTIONS requests with an Allow header on endpoints not implementing OPTIONS themselves
+func (s *Server) EnableAutomaticOptions(b bool) {
+	s.automaticOptionsEnabled = b
+}
+
+// Adds a JSON description of the endpoint resource handlers to automatic OPTIONS responses
+func (s *Server) EnableOptionsDiscovery(b bool) {
+	s.optionsDiscoveryEnabled = b
+}
+
 func (s *Server) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 
 	var timeStart time.Time
 	var timeEnd time.Time
 
-	if s.debugEnableLogRequestDuration {
+	if s.debugEnableLogRequestDuration || s.slowRequestThreshold > 0 || s.metricsObserver != nil {
 		timeStart = time.Now()
 	}
 
+	var recorder *responseWriterRecorder
+	var requestCounter *countingReadCloser
+	if s.metricsObserver != nil || s.auditHook != nil {
+		recorder = newResponseWriterRecorder(writer)
+		writer = recorder
+	}
+	if s.metricsObserver != nil {
+		// Counted as received, before any decompression
+		if request.Body == nil {
+			request.Body = http.NoBody
+		}
+		requestCounter = &countingReadCloser{ReadCloser: request.Body}
+		request.Body = requestCounter
+	}
+
+	matchedRoute := ``
+
 	requestId := "o" // No request id
 	if s.debugEnableLogRequestIdentifier {
 		requestId = s.generateRequestId(timeStart)
@@ -131,7 +424,28 @@ func (s *Server) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 	urlPath := request.URL.Path
 	method := request.Method
 
-	Flog(FLOG_TYPE_INFO, fmt.Sprintf("%s Request %s %s", requestId, method, urlPath))
+	// Created first so that every response can be audited, routing fills in the rest
+	resourceHandlerContext := ResourceHandlerContext{}
+	resourceHandlerContext.Header = request.Header
+	resourceHandlerContext.OriginalPath = urlPath
+	if originalPath, ok := request.Context().Value(originalPathContextKey{}).(string); ok {
+		resourceHandlerContext.OriginalPath = originalPath
+	}
+	resourceHandlerContext.Method = method
+	resourceHandlerContext.TLS = request.TLS
+	resourceHandlerContext.proto = request.Proto
+	resourceHandlerContext.Context = request.Context()
+	if s.debugEnableLogRequestIdentifier {
+		resourceHandlerContext.RequestId = &requestId
+	}
+
+	// Path as it appears in the logs : the matched route template instead, once known, if the raw path must not be logged
+	loggedPath := urlPath
+	if s.logMatchedRoute {
+		loggedPath = `-`
+	}
+
+	Flog(FLOG_TYPE_INFO, fmt.Sprintf("%s Request %s %s", requestId, method, loggedPath))
 
 	if s.debugEnableLogRequestDump {
 		s.dumpRequest(request, requestId)
@@ -139,76 +453,226 @@ func (s *Server) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 
 	// Execute when ServeHTTP returns
 	defer func() {
-		if s.debugEnableLogRequestDuration {
+		if s.metricsObserver != nil {
+			s.metricsObserver(&RequestLogEntry{RequestId: requestId, Method: method, Path: urlPath, Route: matchedRoute, HttpStatus: recorder.httpStatus, Duration: time.Since(timeStart), RequestBytes: requestCounter.bytesRead, ResponseBytes: recorder.bytesWritten})
+		}
+		if s.debugEnableLogRequestDuration || s.slowRequestThreshold > 0 {
 			timeEnd = time.Now()
-			durationMs := timeEnd.Sub(timeStart).Seconds() * 1000
-			Flog(FLOG_TYPE_INFO, fmt.Sprintf("%s Response Duration : %2.2f ms", requestId, durationMs))
+			duration := timeEnd.Sub(timeStart)
+			durationMs := duration.Seconds() * 1000
+			if s.debugEnableLogRequestDuration {
+				Flog(FLOG_TYPE_INFO, fmt.Sprintf("%s Response Duration : %2.2f ms", requestId, durationMs))
+			}
+			if s.slowRequestThreshold > 0 && duration > s.slowRequestThreshold {
+				Flog(FLOG_TYPE_WARNING, fmt.Sprintf("%s Slow request %s %s : %2.2f ms", requestId, method, loggedPath, durationMs))
+			}
 		}
 	}()
 
+	// Closed before the deferred logging above runs, so that the whole compressed body is accounted
+	if s.responseCompressionEnabled && method != `HEAD` && acceptsGzip(request) {
+		compressor := newGzipResponseWriter(writer, s.isCompressibleContentType)
+		defer compressor.Close()
+		writer = compressor
+	}
+
+	// Logged once the response is written, whatever wrote it
+	var bodyLogger *responseBodyLogger
+	if s.debugResponseBodyLogLimit > 0 {
+		bodyLogger = &responseBodyLogger{ResponseWriter: writer, limit: s.debugResponseBodyLogLimit}
+		// Error bodies, e.g. of 404s, may quote the raw path
+		if s.logMatchedRoute && urlPath != const_route_element_separator {
+			bodyLogger.redact(urlPath, request.URL.EscapedPath())
+		}
+		defer s.logResponseBody(bodyLogger, requestId)
+		writer = bodyLogger
+	}
+
+	healthCheck := s.healthEndpointEnabled && s.healthEndpointUrl == urlPath
+
+	// Requests rejected before reaching a resource handler are audited with their status only, health checks excepted
+	if s.auditHook != nil && !healthCheck {
+		defer func() {
+			result := resourceHandlerContext.renderedResult
+			if result == nil {
+				result = &ResourceHandlerResult{HttpStatus: recorder.httpStatus}
+			}
+			s.auditHook(&resourceHandlerContext, result)
+		}()
+	}
+
+	if !s.checkIPAllowlist(writer, request, requestId) {
+		return
+	}
+
+	// Health checks are answered even in maintenance mode
+	if healthCheck {
+		s.serveHealth(writer, requestId)
+		return
+	}
+
+	if s.isInMaintenance() {
+		s.serveMaintenance(writer, requestId)
+		return
+	}
+
 	// Serves documentation if requested and enabled
 	if s.documentationEndpointEnabled && s.documentationEndpointUrl == urlPath {
-		s.serveDocumentation(writer)
+		if s.documentationAuth != nil {
+			if err := s.documentationAuth(request); err != nil {
+				s.denyDocumentation(writer, err, requestId)
+				return
+			}
+		}
+		s.serveDocumentation(writer, request)
 		return
 	}
 
+	// Path used for routing, possibly normalized and rewritten
+	routePath := urlPath
+	if s.collapseSlashes {
+		routePath = collapseSlashes(routePath)
+	}
+	if s.pathRewriter != nil {
+		routePath = s.pathRewriter(routePath)
+	}
+	if routePath != urlPath && !s.logMatchedRoute {
+		Flog(FLOG_TYPE_INFO, fmt.Sprintf("%s Path %s rewritten to %s", requestId, urlPath, routePath))
+	}
+
+	// Cross-origin requests of allowed origins, preflights included
+	corsAllowed := s.cors != nil && s.setCORSHeaders(writer, request)
+
 	// Find route node and associated route variables
-	node, routeVariables, err := s.router.FindNodeByRoute(urlPath)
+	routeRouter := s.routerForHost(request.Host)
+	node, routeVariables, err := routeRouter.FindNodeByRoute(routePath)
+	if rvErrs, ok := err.(RouteVariableErrors); ok {
+		if s.logMatchedRoute {
+			bodyLogger.redact(rvErrs.values()...)
+		}
+		s.renderRouteVariableErrors(writer, rvErrs, requestId)
+		return
+	}
 	if err != nil {
-		message := err.Error()
-		Flog(FLOG_TYPE_ERROR, message)
-		s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusBadRequest, Body: bytes.NewBufferString(message)}, `text/plain`, requestId)
+		// Routing itself failed : server side fault, details are only logged
+		message := fmt.Sprintf("Could not route %s", urlPath)
+		Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Routing error for %s : %s", requestId, loggedPath, err.Error()))
+		s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusInternalServerError, Body: bytes.NewBufferString(message)}, `text/plain`, requestId)
 		return
 	}
 
+	if corsAllowed && isCORSPreflight(request) {
+		registered := node != nil && node.GetEndpoint() != nil && node.GetEndpoint().IsEnabled()
+		if registered {
+			s.serveCORSPreflight(writer, request, node.GetEndpoint().allowedMethods(), requestId)
+			return
+		}
+		if s.cors.PreflightUnknownRoutes {
+			s.serveCORSPreflight(writer, request, nil, requestId)
+			return
+		}
+	}
+
 	// No route node was found
 	if node == nil {
 		message := fmt.Sprintf("Could not find route for %s", urlPath)
-		Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Could not find route for %s", requestId, urlPath))
-		s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusBadRequest, Body: bytes.NewBufferString(message)}, `text/plain`, requestId)
+		Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Could not find route for %s", requestId, loggedPath))
+		s.renderNotFound(writer, request, message, requestId)
 		return
 	}
 
-	// Route was found, create a context first
-	// Add headers, route variables, and requestId if any to it
+	// Route was found, add route variables to the context
 
-	resourceHandlerContext := ResourceHandlerContext{}
 	resourceHandlerContext.RouteVariables = routeVariables
-	resourceHandlerContext.Header = request.Header
-	if s.debugEnableLogRequestIdentifier {
-		resourceHandlerContext.RequestId = &requestId
+	if variable, ok := node.(*routerNodeVariable); ok && variable.IsCatchAll() {
+		resourceHandlerContext.catchAllIdentifier = variable.identifier
 	}
 
-	// No endpoint registered on that node
+	// Canceled when ServeHTTP returns, at the latest once the default deadline is reached
+	if s.requestDeadline > 0 {
+		var cancel context.CancelFunc
+		resourceHandlerContext.Context, cancel = context.WithTimeout(resourceHandlerContext.Context, s.requestDeadline)
+		defer cancel()
+	}
+
+	// No endpoint registered on that node, e.g. /users when only /users/{id} is : the route does not exist
 	if node.GetEndpoint() == nil {
-		message := fmt.Sprintf("No endpoint found for this route %s", urlPath)
-		Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s No endpoint found for this route %s", requestId, urlPath))
-		s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusInternalServerError, Body: bytes.NewBufferString(message)}, `text/plain`, requestId)
+		message := fmt.Sprintf("Could not find route for %s", urlPath)
+		Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s No endpoint found for this route %s", requestId, loggedPath))
+		s.renderNotFound(writer, request, message, requestId)
+		return
+	}
+
+	matchedRoute = node.GetEndpoint().GetRoute()
+	resourceHandlerContext.Route = matchedRoute
+	resourceHandlerContext.Version = node.GetEndpoint().version
+	if s.logMatchedRoute {
+		loggedPath = matchedRoute
+		Flog(FLOG_TYPE_INFO, fmt.Sprintf("%s Matched route %s", requestId, loggedPath))
+	}
+
+	// Disabled endpoint : answers as if it was not registered
+	if !node.GetEndpoint().IsEnabled() {
+		message := fmt.Sprintf("Could not find route for %s", urlPath)
+		Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Endpoint is disabled for this route %s", requestId, loggedPath))
+		s.renderNotFound(writer, request, message, requestId)
+		return
+	}
+
+	// Typed values of the route variables
+	resourceHandlerContext.routeVariableValues, err = routeRouter.ParseRouteVariableValues(node.GetEndpoint().GetRoute(), routeVariables)
+	if rvErrs, ok := err.(RouteVariableErrors); ok {
+		if s.logMatchedRoute {
+			bodyLogger.redact(rvErrs.values()...)
+		}
+		s.renderRouteVariableErrors(writer, rvErrs, requestId)
+		return
+	}
+	if err != nil {
+		message := err.Error()
+		Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s %s", requestId, message))
+		s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusBadRequest, Body: bytes.NewBufferString(message)}, `text/plain`, requestId)
+		return
+	}
+
+	// Automatic OPTIONS, if enabled and not implemented by the endpoint
+	if s.automaticOptionsEnabled && method == `OPTIONS` && node.GetEndpoint().needsAutomaticOptions() {
+		s.serveAutomaticOptions(writer, request, node.GetEndpoint(), requestId)
 		return
 	}
 
 	// Parse Content-Type and Accept headers
 
-	contentTypeParser, err := newContentTypeHeaderParser(request.Header.Get(`Content-Type`))
+	contentTypeValue := request.Header.Get(`Content-Type`)
+	if contentTypeValue == `` && request.ContentLength != 0 && s.defaultRequestContentType != `` {
+		contentTypeValue = s.defaultRequestContentType
+	}
+
+	contentTypeParser, err := newContentTypeHeaderParser(contentTypeValue)
 	if err != nil {
 		message := fmt.Sprintf("Invalid Content-Type header : %s", err.Error())
 		Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Invalid Content-Type header : %s", requestId, err.Error()))
-		s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusBadRequest, Body: bytes.NewBufferString(message)}, `text/plain`, requestId)
+		s.renderValidationError(writer, message, []ProblemInvalidParam{{Name: `Content-Type`, Reason: err.Error()}}, requestId)
 		return
 	}
 
-	acceptParser, err := newAcceptHeaderParser(request.Header.Get(`Accept`))
+	acceptValue := request.Header.Get(`Accept`)
+	if acceptValue == `` && s.missingAcceptAsAny {
+		acceptValue = `*/*`
+	}
+
+	acceptParser, err := newAcceptHeaderParser(acceptValue)
 	if err != nil {
 		message := fmt.Sprintf("Invalid Accept header : %s", err.Error())
 		Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Invalid Accept header : %s", requestId, err.Error()))
-		s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusBadRequest, Body: bytes.NewBufferString(message)}, `text/plain`, requestId)
+		s.renderValidationError(writer, message, []ProblemInvalidParam{{Name: `Accept`, Reason: err.Error()}}, requestId)
 		return
 	}
 
 	if !acceptParser.HasAcceptElement() {
 		message := fmt.Sprintf("No valid Accept header was given")
 		Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s No valid Accept header was given", requestId))
-		s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusBadRequest, Body: bytes.NewBufferString(message)}, `text/plain`, requestId)
+		s.renderValidationError(writer, message, []ProblemInvalidParam{{Name: `Accept`, Reason: `missing`}}, requestId)
 		return
 	}
 
@@ -218,12 +682,24 @@ func (s *Server) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 
 	if len(availableResourceImplementations) == 0 {
 		message := fmt.Sprintf("No resource found on this route %s", urlPath)
-		Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s No resource found on this route %s", requestId, urlPath))
+		Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s No resource found on this route %s", requestId, loggedPath))
 		s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusInternalServerError, Body: bytes.NewBufferString(message)}, `text/plain`, requestId)
 		return
 	}
 
-	matchingResource, contentTypeIn, contentTypeOut := endp.FindMatchingResource(method, &contentTypeParser, &acceptParser)
+	// HEAD responses keep the Content-Length of the result body, which is dropped instead of written
+	if method == `HEAD` {
+		writer = &headResponseWriter{ResponseWriter: writer}
+	}
+
+	// Automatic HEAD : the GET resource handler is used
+	resourceMethod := method
+	automaticHead := s.automaticHeadEnabled && method == `HEAD` && endp.needsAutomaticHead()
+	if automaticHead {
+		resourceMethod = `GET`
+	}
+
+	matchingResource, contentTypeIn, contentTypeOut := endp.FindMatchingResource(resourceMethod, &contentTypeParser, &acceptParser)
 
 	if matchingResource == nil {
 		message := fmt.Sprintf("No available resource matching the given Method, Content-Type and Accept")
@@ -238,9 +714,86 @@ func (s *Server) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 	resourceHandlerContext.ContentTypeIn = contentTypeIn
 	resourceHandlerContext.ContentTypeOut = contentTypeOut
 
+	// Check header parameters first : fail fast, before reading a potentially large body
+	for hpKey, hpObject := range matchingResource.HeaderParameters {
+		message := hpObject.Validate(hpKey, request.Header)
+		if message != `` {
+			Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s %s", requestId, message))
+			s.renderValidationError(writer, message, []ProblemInvalidParam{{Name: hpKey, Reason: message}}, requestId)
+			return
+		}
+	}
+
+	// Counted on the raw query string, so that an excessive one is not even parsed, nor the body read
+	if s.maxQueryParameters > 0 && countQueryParameters(request.URL.RawQuery) > s.maxQueryParameters {
+		message := fmt.Sprintf("Too many query parameters, at most %d are allowed", s.maxQueryParameters)
+		Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Too many query parameters, at most %d are allowed", requestId, s.maxQueryParameters))
+		s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusBadRequest, Body: bytes.NewBufferString(message)}, `text/plain`, requestId)
+		return
+	}
+
+	// The client waits for the go-ahead before sending the body, which is only given once it is first read
+	if s.expectContinueHook != nil && isExpectContinue(request.Header) && s.rejectExpectContinue(writer, &resourceHandlerContext, requestId) {
+		return
+	}
+
+	// Decompress the request body if needed
+
+	var bodyReader io.Reader = request.Body
+
+	if s.requestDecompressionEnabled && request.Header.Get(`Content-Encoding`) != `` {
+		decompressingReader, err := newDecompressingReader(request.Header.Get(`Content-Encoding`), request.Body, s.requestDecompressionMaxSize)
+		if err != nil {
+			httpStatus := http.StatusBadRequest
+			if err == errUnsupportedContentEncoding {
+				httpStatus = http.StatusUnsupportedMediaType
+			}
+			message := fmt.Sprintf("Could not decompress request body : %s", err.Error())
+			Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Could not decompress request body : %s", requestId, err.Error()))
+			s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: httpStatus, Body: bytes.NewBufferString(message)}, `text/plain`, requestId)
+			return
+		}
+		bodyReader = decompressingReader
+		// Handlers only ever see the decompressed body
+		request.Header.Del(`Content-Encoding`)
+	}
+
 	// Read request body
 
-	bodyInBytes, err := ioutil.ReadAll(request.Body)
+	var limitedBodyReader *maxBodyReader
+	if s.maxBodySize > 0 {
+		limitedBodyReader = &maxBodyReader{reader: bodyReader, remaining: s.maxBodySize, truncate: s.maxBodyTruncate}
+		bodyReader = limitedBodyReader
+	}
+
+	var bodyInBytes []byte
+	var spilledBody *os.File
+	var bodySize int64
+	if limitedBodyReader != nil && !s.maxBodyTruncate && request.ContentLength > s.maxBodySize {
+		// Announced as too large : not even read
+		err = errRequestBodyTooLarge
+	} else if matchingResource.StreamBody {
+		// Read by the handler : only an announced body is known of, -1 when chunked
+		bodySize = request.ContentLength
+		resourceHandlerContext.bodyStream = bodyReader
+	} else {
+		bodyInBytes, spilledBody, bodySize, err = readRequestBody(bodyReader, s.bodySpillThreshold)
+	}
+	if spilledBody != nil {
+		defer releaseSpilledBody(spilledBody)
+	}
+	if err == errRequestBodyTooLarge {
+		message := fmt.Sprintf("Request body exceeds %d bytes", s.maxBodySize)
+		Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Request body exceeds %d bytes", requestId, s.maxBodySize))
+		s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusRequestEntityTooLarge, Body: bytes.NewBufferString(message)}, `text/plain`, requestId)
+		return
+	}
+	if err == errDecompressedBodyTooLarge {
+		message := fmt.Sprintf("Decompressed request body exceeds %d bytes", s.requestDecompressionMaxSize)
+		Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Decompressed request body exceeds %d bytes", requestId, s.requestDecompressionMaxSize))
+		s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusRequestEntityTooLarge, Body: bytes.NewBufferString(message)}, `text/plain`, requestId)
+		return
+	}
 	if err != nil {
 		message := fmt.Sprintf("Could not read request body")
 		Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Could not read request body", requestId))
@@ -248,13 +801,29 @@ func (s *Server) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 		return
 	}
 
-	if resourceHandlerContext.ContentTypeIn == nil && len(bodyInBytes) > 0 {
+	if limitedBodyReader != nil && limitedBodyReader.exceeded {
+		Flog(FLOG_TYPE_WARNING, fmt.Sprintf("%s Request body truncated to %d bytes", requestId, s.maxBodySize))
+		resourceHandlerContext.BodyTruncated = true
+	}
+
+	if resourceHandlerContext.ContentTypeIn == nil && bodySize > 0 && !matchingResource.AcceptAnyBody {
 		message := fmt.Sprintf("Body is not allowed for this resource")
 		Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Body is not allowed for this resource", requestId))
-		s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusBadRequest, Body: bytes.NewBufferString(message)}, `text/plain`, requestId)
+		s.renderValidationError(writer, message, nil, requestId)
+		return
+	}
+	if bodySize == 0 && matchingResource.requiresBody(s.requireBodyMethods) {
+		message := fmt.Sprintf("Body is required for this resource")
+		Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Body is required for this resource", requestId))
+		s.renderValidationError(writer, message, nil, requestId)
 		return
 	}
 	resourceHandlerContext.Body = bytes.NewBuffer(bodyInBytes)
+	if spilledBody != nil {
+		resourceHandlerContext.BodyReader = spilledBody
+	} else {
+		resourceHandlerContext.BodyReader = bytes.NewReader(bodyInBytes)
+	}
 
 	// Create a new instance from factory and executes it
 	resource := matchingResource
@@ -268,16 +837,22 @@ func (s *Server) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 	// Check and provide query parameters
 
 	resourceHandlerContext.QueryParameters = make(map[string]string)
+	resourceHandlerContext.queryParameters = resource.QueryParameters
+
 	urlValues := request.URL.Query()
 
+	if s.debugEnableLogQueryParameters {
+		s.logQueryParameters(resource.QueryParameters, urlValues, requestId)
+	}
+
 	for qpKey, qpObject := range resource.QueryParameters {
-		qpValue := urlValues.Get(qpKey)
+		qpValue := qpObject.Normalize(qpObject.GetValue(qpKey, urlValues))
 		if qpValue == `` {
 			qpValue = qpObject.DefaultValue
 			if !qpObject.IsValidType(qpValue) {
 				message := fmt.Sprintf("Query parameter %s default value must be of kind %s", qpKey, qpObject.Kind)
 				Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Query parameter %s default value must be of kind %s", requestId, qpKey, qpObject.Kind))
-				s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusBadRequest, Body: bytes.NewBufferString(message)}, `text/plain`, requestId)
+				s.renderValidationError(writer, message, []ProblemInvalidParam{{Name: qpKey, Reason: fmt.Sprintf("default value must be of kind %s", qpObject.Kind)}}, requestId)
 				return
 			}
 		}
@@ -285,27 +860,105 @@ func (s *Server) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 		if !qpObject.IsValidType(qpValue) {
 			message := fmt.Sprintf("Query parameter %s must be of kind %s", qpKey, qpObject.Kind)
 			Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Query parameter %s must be of kind %s", requestId, qpKey, qpObject.Kind))
-			s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusBadRequest, Body: bytes.NewBufferString(message)}, `text/plain`, requestId)
+			s.renderValidationError(writer, message, []ProblemInvalidParam{{Name: qpKey, Reason: fmt.Sprintf("must be of kind %s", qpObject.Kind)}}, requestId)
 			return
 		} else {
 			// Validate query param
-			validator := qpObject.FormatValidator
+			validator := qpObject.FindFailingValidator(qpValue)
 			if validator != nil {
-				if !validator.IsValid(qpValue) {
-					message := fmt.Sprintf("Invalid Query Parameter, %s", validator.GetErrorMessage())
-					Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Invalid Query Parameter, %s", requestId, validator.GetErrorMessage()))
-					s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusBadRequest, Body: bytes.NewBufferString(message)}, `text/plain`, requestId)
-					return
-				}
+				message := fmt.Sprintf("Invalid Query Parameter, %s", validator.GetErrorMessage())
+				Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Invalid Query Parameter, %s", requestId, validator.GetErrorMessage()))
+				s.renderValidationError(writer, message, []ProblemInvalidParam{{Name: qpKey, Reason: validator.GetErrorMessage()}}, requestId)
+				return
 			}
 			// Creates a query parameter for the resource to access it
 			resourceHandlerContext.QueryParameters[qpKey] = qpValue
 		}
 	}
 
+	// The ETag is computed from the body, which the hint does not give
+	resourceHandlerContext.contentLengthHintAllowed = automaticHead && !s.autoETagEnabled
+
+	resourceHandlerContext.DryRun = resource.AllowDryRun && isDryRunRequest(urlValues, request.Header)
+
+	// Looked up once the middlewares ran, so that a response is only replayed to a caller allowed to send the request
+	if s.idempotencyStore != nil && !resourceHandlerContext.DryRun && isIdempotencyMethod(method) && request.Header.Get(const_idempotency_key_header) != `` {
+		resourceHandlerContext.idempotency = &idempotentRequest{key: idempotencyKey(request)}
+		defer s.releaseIdempotencyKey(resourceHandlerContext.idempotency)
+	}
+
 	// Everything went fine, finally we can serve the request
-	result := resource.Implementation.Execute(&resourceHandlerContext)
-	s.renderResourceResult(writer, &result, *resourceHandlerContext.ContentTypeOut, requestId)
+	resourceHandlerContext.responseWriter = writer
+	resourceHandlerContext.codecs = s.codecs
+	// Checked last, so that a request rejected on validation can be fixed and sent again with the same nonce
+	if s.nonceStore != nil && !s.checkNonce(writer, request, requestId) {
+		return
+	}
+
+	if s.contextProvider != nil && !s.provideContext(writer, &resourceHandlerContext, requestId) {
+		return
+	}
+
+	// Kept as is for the release, should the limit be changed meanwhile
+	if writeSlots := s.writeSlots; writeSlots != nil && isWriteMethod(method) {
+		if !s.acquireWriteSlot(writer, writeSlots, resourceHandlerContext.Context, requestId) {
+			return
+		}
+		defer func() { <-writeSlots }()
+	}
+
+	sharedKey := ``
+	if endp.singleFlight && method == `GET` && !resourceHandlerContext.DryRun {
+		sharedKey = singleFlightKey(request, *resourceHandlerContext.ContentTypeOut)
+	}
+
+	result := s.executeResourceHandler(endp, method, resource, &resourceHandlerContext, sharedKey, requestId)
+
+	if isContentLengthHintResult(&result, &resourceHandlerContext) {
+		result = applyCacheControl(resource, result)
+		s.renderContentLengthHint(writer, &result, &resourceHandlerContext, requestId)
+		return
+	}
+
+	// Rendered as it was kept
+	if resourceHandlerContext.idempotency != nil && resourceHandlerContext.idempotency.replayed {
+		s.renderHandlerResult(writer, &result, result.ContentType, &resourceHandlerContext, requestId)
+		return
+	}
+
+	result = applyCacheControl(resource, result)
+
+	// Serialize a Go value payload with the codec of the negotiated content type, unless the handler overrides it
+	resultContentType := *resourceHandlerContext.ContentTypeOut
+	if result.ContentType != `` {
+		resultContentType = result.ContentType
+	}
+
+	err = s.encodeResultPayload(&result, resultContentType)
+	if err != nil {
+		message := fmt.Sprintf("Could not serialize the response as %s", resultContentType)
+		Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Could not serialize the response as %s : %s", requestId, resultContentType, err.Error()))
+		s.renderResourceResult(writer, &ResourceHandlerResult{HttpStatus: http.StatusInternalServerError, Body: bytes.NewBufferString(message)}, `text/plain`, requestId)
+		return
+	}
+
+	if resource.SparseFieldsets && !result.Raw && urlValues.Get(const_sparse_fieldsets_query_parameter) != `` {
+		s.applySparseFieldsets(&result, resultContentType, urlValues.Get(const_sparse_fieldsets_query_parameter), requestId)
+	}
+
+	if s.autoETagEnabled {
+		result = s.applyAutoETag(request, result)
+	}
+
+	if resourceHandlerContext.idempotency != nil {
+		s.keepIdempotentResponse(resourceHandlerContext.idempotency, &result, resultContentType)
+	}
+
+	if s.debugEnableCheckContentTypes && !resource.IsDeclaredResponseContentType(result.HttpStatus, resultContentType) {
+		Flog(FLOG_TYPE_WARNING, fmt.Sprintf("%s Content type %s is not declared for status %d", requestId, resultContentType, result.HttpStatus))
+	}
+
+	s.renderHandlerResult(writer, &result, *resourceHandlerContext.ContentTypeOut, &resourceHandlerContext, requestId)
 
 }
 
@@ -314,23 +967,138 @@ func (s *Server) generateRequestId(t time.Time) string {
 	return xbCodec.Encode(rand.Int63()) + xbCodec.Encode(t.UnixNano())
 }
 
-func (s *Server) dumpRequest(request *http.Request, requestId string) {
-	jsonRequest, _ := json.MarshalIndent(request, "", "")
+// Reports every rejected route variable in a single 400 response
+func (s *Server) renderRouteVariableErrors(writer http.ResponseWriter, rvErrs RouteVariableErrors, requestId string) {
+
+	message := rvErrs.Error()
+
+	invalidParams := []ProblemInvalidParam{}
+	for _, rvErr := range rvErrs {
+		invalidParams = append(invalidParams, ProblemInvalidParam{Name: rvErr.Identifier, Reason: fmt.Sprintf("must be of kind %s", rvErr.Kind)})
+	}
+
+	// The rejected values are parts of the raw path, only logged if it may be
+	if s.logMatchedRoute {
+		rejected := []string{}
+		for _, invalidParam := range invalidParams {
+			rejected = append(rejected, fmt.Sprintf(`'%s' %s`, invalidParam.Name, invalidParam.Reason))
+		}
+		Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Route variables rejected : %s", requestId, strings.Join(rejected, `, `)))
+	} else {
+		Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s %s", requestId, message))
+	}
+
+	s.renderValidationError(writer, message, invalidParams, requestId)
+}
+
+func (s *Server) logQueryParameters(queryParameters map[string]QueryParameter, urlValues url.Values, requestId string) {
+
+	declared := []string{}
+	known := make(map[string]bool)
+	for qpKey, qpObject := range queryParameters {
+		declared = append(declared, qpKey)
+		known[qpKey] = true
+		for _, alias := range qpObject.Aliases {
+			known[alias] = true
+		}
+	}
+
+	supplied := []string{}
+	ignored := []string{}
+	for qpKey := range urlValues {
+		supplied = append(supplied, qpKey)
+		if !known[qpKey] {
+			ignored = append(ignored, qpKey)
+		}
+	}
+
+	sort.Strings(declared)
+	sort.Strings(supplied)
+	sort.Strings(ignored)
 
-	Flog(FLOG_TYPE_DEBUG, fmt.Sprintf("%s Dumping request start", requestId))
-	fmt.Printf("%s\n", jsonRequest)
-	Flog(FLOG_TYPE_DEBUG, fmt.Sprintf("%s Dumping request end", requestId))
+	Flog(FLOG_TYPE_DEBUG, fmt.Sprintf("%s Query parameters declared : [%s] supplied : [%s] ignored : [%s]", requestId, strings.Join(declared, `, `), strings.Join(supplied, `, `), strings.Join(ignored, `, `)))
 }
 
 func (s *Server) NewRouteVariableType(kind string, rvtype RouteVariableType) error {
-	return s.router.NewRouteVariableType(kind, rvtype)
+	return s.recordRegistrationError(s.router.NewRouteVariableType(kind, rvtype))
+}
+
+// Keeps track of registration errors for Validate, returns the given error
+func (s *Server) recordRegistrationError(err error) error {
+	if err != nil {
+		s.registrationErrors = append(s.registrationErrors, err)
+	}
+	return err
+}
+
+// Returns all the errors met while registering endpoints and route variable types, in order,
+// so that every misconfiguration can be reported at once before ListenAndServe
+func (s *Server) Validate() []error {
+	return append([]error{}, s.registrationErrors...)
+}
+
+// Returns the number of registered endpoints and resource handlers, and the uptime since ListenAndServe
+func (s *Server) Stats() ServerStats {
+
+	stats := ServerStats{}
+	stats.Endpoints, stats.ResourceHandlers = s.router.CountEndpoints()
+	for _, hr := range s.hostRouters {
+		endpoints, resourceHandlers := hr.router.CountEndpoints()
+		stats.Endpoints += endpoints
+		stats.ResourceHandlers += resourceHandlers
+	}
+
+	s.httpServerMutex.Lock()
+	if !s.startTime.IsZero() {
+		stats.Uptime = time.Since(s.startTime)
+	}
+	s.httpServerMutex.Unlock()
+
+	return stats
+}
+
+// Enables or disables a registered route at runtime, a disabled route answers 404 but stays documented
+func (s *Server) SetEndpointEnabled(route string, enabled bool) error {
+
+	routers := []*router{s.router}
+	for _, hr := range s.hostRouters {
+		routers = append(routers, hr.router)
+	}
+
+	found := false
+	for _, r := range routers {
+		node, err := r.FindNodeByPattern(route)
+		if err == nil {
+			node.GetEndpoint().SetEnabled(enabled)
+			found = true
+		}
+	}
+
+	if !found {
+		return errors.New(fmt.Sprintf(`Route %s is not registered`, route))
+	}
+
+	Flog(FLOG_TYPE_ACTION, fmt.Sprintf("Endpoint %s enabled : %t\n", TermColorEscape(route, TERM_COLOR_BLUE), enabled))
+
+	return nil
+}
+
+// Returns the route variable names declared by a registered route, in order
+func (s *Server) RouteVariables(route string) ([]string, error) {
+	return s.router.GetRouteVariableIdentifiers(route)
 }
 
 func (s *Server) SetInternalResourceResultRenderer(r InternalResourceResultRenderer) {
 	s.internalResourceResultRenderer = r
 }
 
-func (s *Server) renderResourceResult(writer http.ResponseWriter, result *ResourceHandlerResult, contentType string, requestId string) {
+// Returns the result as rendered, e.g. with its status coerced to 204
+func (s *Server) renderResourceResult(writer http.ResponseWriter, result *ResourceHandlerResult, contentType string, requestId string) *ResourceHandlerResult {
+	return s.renderResourceResultForContext(writer, result, contentType, nil, requestId)
+}
+
+// Renders the result of the request of ctx, used for the status line of a result with a reason phrase, nil if none
+func (s *Server) renderResourceResultForContext(writer http.ResponseWriter, result *ResourceHandlerResult, contentType string, ctx *ResourceHandlerContext, requestId string) *ResourceHandlerResult {
 
 	if s.internalResourceResultRenderer == nil {
 		panicMsg := "Internal resource result renderer is invalid"
@@ -338,7 +1106,30 @@ func (s *Server) renderResourceResult(writer http.ResponseWriter, result *Resour
 		panic(panicMsg)
 	}
 
-	s.internalResourceResultRenderer.Render(writer, result, contentType, requestId)
+	// The handler has the final say on the content type
+	if result.ContentType != `` {
+		contentType = result.ContentType
+	}
+
+	for name, values := range result.Header {
+		// One Set-Cookie line per cookie, as cookies can not be comma-joined, keeping those already set
+		if http.CanonicalHeaderKey(name) == `Set-Cookie` {
+			for _, value := range values {
+				writer.Header().Add(name, value)
+			}
+			continue
+		}
+		writer.Header()[name] = values
+	}
+
+	if result.Stream != nil {
+		s.streamResourceResult(writer, result, contentType, requestId)
+	} else {
+		result, contentType = s.prepareResourceResult(result, contentType, requestId)
+		if result.ReasonPhrase == `` || !s.renderWithReasonPhrase(writer, result, contentType, ctx, requestId) {
+			s.internalResourceResultRenderer.Render(writer, result, contentType, requestId)
+		}
+	}
 
 	var FhttpStatus string
 
@@ -365,6 +1156,144 @@ func (s *Server) renderResourceResult(writer http.ResponseWriter, result *Resour
 
 	Flog(FLOG_TYPE_INFO, fmt.Sprintf("%s Response result %s", requestId, FhttpStatus))
 
+	return result
+}
+
+// Renders the result of a resource handler, then calls the write error hook, the rendered result is kept for the audit hook
+func (s *Server) renderHandlerResult(writer http.ResponseWriter, result *ResourceHandlerResult, contentType string, ctx *ResourceHandlerContext, requestId string) {
+
+	if s.writeErrorHandler == nil {
+		result = s.renderResourceResultForContext(writer, result, contentType, ctx, requestId)
+	} else {
+		recorder := &writeErrorRecorder{ResponseWriter: writer}
+		result = s.renderResourceResultForContext(recorder, result, contentType, ctx, requestId)
+		if recorder.err != nil {
+			s.writeErrorHandler(ctx, recorder.err)
+		}
+	}
+
+	ctx.renderedResult = result
+}
+
+// Post-processes a response body, e.g. to minify it or wrap it in an envelope
+type ResponseTransformer func(body []byte) ([]byte, error)
+
+// Rewrites the URL path before routing, e.g. for legacy aliases, the original path stays available in the context
+func (s *Server) SetPathRewriter(rewriter func(path string) string) {
+	s.pathRewriter = rewriter
+}
+
+// Logs the matched route template, e.g. /users/{id}, instead of the raw URL path which may hold personal data
+// The path is also redacted from request dumps and logged response bodies, and rejected route variable values are not logged
+func (s *Server) SetLogMatchedRoute(b bool) {
+	s.logMatchedRoute = b
+}
+
+// Collapses repeated slashes in the URL path before routing, e.g. /users//42 is routed as /users/42
+// The original path stays available in the context
+func (s *Server) SetCollapseSlashes(b bool) {
+	s.collapseSlashes = b
+}
+
+func collapseSlashes(path string) string {
+	for strings.Contains(path, `//`) {
+		path = strings.Replace(path, `//`, `/`, -1)
+	}
+	return path
+}
+
+// Key of the request context value holding the path as requested, before the root handler collapsed its slashes
+type originalPathContextKey struct{}
+
+// Handler of the underlying http servers : the mux, which would redirect paths with repeated slashes, is given them
+// collapsed when the server collapses them itself, so that only the paths of its pattern still reach it
+func (s *Server) rootHandler() http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if s.collapseSlashes && strings.Contains(request.URL.Path, `//`) {
+			originalPath := request.URL.Path
+			collapsedUrl := *request.URL
+			collapsedUrl.Path = collapseSlashes(originalPath)
+			collapsedUrl.RawPath = ``
+			request = request.WithContext(context.WithValue(request.Context(), originalPathContextKey{}, originalPath))
+			request.URL = &collapsedUrl
+		}
+		s.mux.ServeHTTP(writer, request)
+	})
+}
+
+// Treats requests without an Accept header as accepting anything ( */* ) instead of rejecting them
+func (s *Server) SetMissingAcceptAsAny(b bool) {
+	s.missingAcceptAsAny = b
+}
+
+// Renders 200 results without a body as 204 No Content
+func (s *Server) SetCoerceEmptyBodyTo204(b bool) {
+	s.coerceEmptyBodyTo204 = b
+}
+
+// Content type assumed for request bodies sent without a Content-Type header, e.g. application/json
+// The body must be announced, by a non zero Content-Length or a chunked transfer encoding
+func (s *Server) SetDefaultRequestContentType(mediaType string) {
+	s.defaultRequestContentType = mediaType
+}
+
+// Requests of the given methods, e.g. POST and PUT, must carry a body when the matched resource handler consumes one,
+// they are answered 400 otherwise ; ResourceHandler.BodyRequirement overrides it
+func (s *Server) SetRequireBodyForMethods(methods ...string) {
+	s.requireBodyMethods = methods
+}
+
+// Rejects requests whose query string holds more than n parameters with a 400, 0 for no limit
+func (s *Server) SetMaxQueryParameters(n int) {
+	s.maxQueryParameters = n
+}
+
+// Number of parameters of a raw query string, empty ones included
+func countQueryParameters(rawQuery string) int {
+	if rawQuery == `` {
+		return 0
+	}
+	return strings.Count(rawQuery, `&`) + 1
+}
+
+// Registers a transformer applied to every response body rendered with the given Content-Type
+func (s *Server) RegisterResponseTransformer(contentType string, fn func(body []byte) ([]byte, error)) {
+	s.responseTransformers[contentType] = fn
+}
+
+// Applies the transformer registered for the content type if any, a failing transformer results in a 500
+func (s *Server) transformResourceResult(result *ResourceHandlerResult, contentType string, requestId string) (*ResourceHandlerResult, string) {
+
+	transformer, ok := s.responseTransformers[contentType]
+	if !ok || result.Body == nil || result.Raw {
+		return result, contentType
+	}
+
+	transformedBody, err := transformer(result.Body.Bytes())
+	if err != nil {
+		message := fmt.Sprintf("Could not transform response body")
+		Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Could not transform %s response body : %s", requestId, contentType, err.Error()))
+		return &ResourceHandlerResult{HttpStatus: http.StatusInternalServerError, Body: bytes.NewBufferString(message)}, `text/plain`
+	}
+
+	transformedResult := *result
+	transformedResult.Body = bytes.NewBuffer(transformedBody)
+
+	return &transformedResult, contentType
+}
+
+// Turns a handler result into the body actually rendered : transformers and status coercion, a payload is already serialized by ServeHTTP
+func (s *Server) prepareResourceResult(result *ResourceHandlerResult, contentType string, requestId string) (*ResourceHandlerResult, string) {
+
+	result, contentType = s.transformResourceResult(result, contentType, requestId)
+
+	if s.coerceEmptyBodyTo204 && result.HttpStatus == http.StatusOK && (result.Body == nil || result.Body.Len() == 0) {
+		coercedResult := *result
+		coercedResult.HttpStatus = http.StatusNoContent
+		result = &coercedResult
+	}
+
+	return result, contentType
 }
 
 type InternalResourceResultRenderer interface {
@@ -0,0 +1,1081 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Server tests, and the helpers shared by all tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Route variable type matching any part
+type anyRouteVariableType struct{}
+
+func (anyRouteVariableType) Matches(value string) bool {
+	return true
+}
+
+// Route variable type matching digits only
+type digitsRouteVariableType struct{}
+
+func (digitsRouteVariableType) Matches(value string) bool {
+	return regexp.MustCompile(`^[0-9]+$`).MatchString(value)
+}
+
+// Resource handler answering a plain text body
+func textResourceHandler(method string, text string) ResourceHandler {
+	return ResourceHandler{Method: method, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+		return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(text)}
+	})}
+}
+
+// Serves a request through the handler, accepting anything unless an Accept header is given
+func serveTestRequest(handler http.Handler, method string, target string, body io.Reader, header map[string]string) *httptest.ResponseRecorder {
+
+	request := httptest.NewRequest(method, target, body)
+	request.Header.Set(`Accept`, `*/*`)
+	for name, value := range header {
+		request.Header.Set(name, value)
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	return recorder
+}
+
+func TestRouteVariables(t *testing.T) {
+
+	s := NewServer(`/`, `:0`)
+	s.NewRouteVariableType(`any`, anyRouteVariableType{})
+	s.NewRouteVariableType(`digits`, digitsRouteVariableType{})
+	s.NewEndpoint(`/tenants/{tenantId:any}/users/{userId:digits}`, textResourceHandler(`GET`, `user`))
+	s.NewEndpoint(`/status`, textResourceHandler(`GET`, `up`))
+
+	tests := []struct {
+		route       string
+		identifiers []string
+		registered  bool
+	}{
+		{`/tenants/{tenantId:any}/users/{userId:digits}`, []string{`tenantId`, `userId`}, true},
+		{`/status`, []string{}, true},
+		{`/tenants/{tenantId:any}`, nil, false},
+		{`/unknown`, nil, false},
+		{`status`, nil, false},
+	}
+
+	for _, test := range tests {
+		identifiers, err := s.RouteVariables(test.route)
+		if registered := err == nil; registered != test.registered {
+			t.Errorf(`%s : registered %t, expected %t`, test.route, registered, test.registered)
+			continue
+		}
+		if strings.Join(identifiers, `,`) != strings.Join(test.identifiers, `,`) {
+			t.Errorf(`%s : got %v, expected %v`, test.route, identifiers, test.identifiers)
+		}
+	}
+}
+
+// Returns what is logged while running fn
+func captureLog(fn func()) string {
+
+	logged := new(bytes.Buffer)
+	log.SetOutput(logged)
+	defer log.SetOutput(os.Stderr)
+
+	fn()
+
+	return logged.String()
+}
+
+func TestRoutingStatuses(t *testing.T) {
+
+	s := NewServer(`/`, `:0`)
+	s.NewRouteVariableType(`digits`, digitsRouteVariableType{})
+	s.NewEndpoint(`/users/{id:digits}`, textResourceHandler(`GET`, `user`))
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{`/users/42`, http.StatusOK},
+		{`/users/abc`, http.StatusBadRequest},
+		{`/unknown`, http.StatusNotFound},
+		{`/users`, http.StatusNotFound},
+		{`/`, http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		if recorder := serveTestRequest(s, `GET`, test.path, nil, nil); recorder.Code != test.status {
+			t.Errorf(`%s : expected %d, got %d`, test.path, test.status, recorder.Code)
+		}
+	}
+}
+
+func TestOnStart(t *testing.T) {
+
+	failure := errors.New(`database unreachable`)
+
+	tests := []struct {
+		name    string
+		results []error
+		ran     string
+		err     error
+	}{
+		{`all succeed`, []error{nil, nil}, `01`, http.ErrServerClosed},
+		{`first fails`, []error{failure, nil}, `0`, failure},
+		{`last fails`, []error{nil, failure}, `01`, failure},
+	}
+
+	for _, test := range tests {
+		ran := ``
+		s := NewServer(`/`, `127.0.0.1:0`)
+		for i, result := range test.results {
+			i, result := i, result
+			s.OnStart(func() error {
+				ran += fmt.Sprint(i)
+				return result
+			})
+		}
+
+		served := make(chan error)
+		go func() {
+			served <- s.ListenAndServe()
+		}()
+		if test.err == http.ErrServerClosed {
+			for s.Stats().Uptime == 0 {
+				time.Sleep(time.Millisecond)
+			}
+			s.Shutdown(context.Background())
+		}
+
+		if err := <-served; err != test.err {
+			t.Errorf(`%s : ListenAndServe returned %v, expected %v`, test.name, err, test.err)
+		}
+		if ran != test.ran {
+			t.Errorf(`%s : ran %q, expected %q`, test.name, ran, test.ran)
+		}
+	}
+}
+
+func TestShutdownDrainsThenRunsOnShutdown(t *testing.T) {
+
+	path := filepath.Join(unixSocketTestDir(t), `server.sock`)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	ran := make(chan string, 2)
+
+	s := NewServer(`/`, `:0`)
+	s.NewEndpoint(`/slow`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+		close(started)
+		<-release
+		return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(`done`)}
+	})})
+	s.OnShutdown(func() { ran <- `first` })
+	s.OnShutdown(func() { ran <- `second` })
+	go s.ListenAndServeUnix(path)
+
+	responses := make(chan int)
+	go func() {
+		request, _ := http.NewRequest(`GET`, `http://unix/slow`, nil)
+		request.Header.Set(`Accept`, `text/plain`)
+		for {
+			if response, err := unixTestClient(path).Do(request); err == nil {
+				response.Body.Close()
+				responses <- response.StatusCode
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+	<-started
+
+	shutdown := make(chan error)
+	go func() {
+		shutdown <- s.Shutdown(context.Background())
+	}()
+
+	// The request in flight is drained first
+	select {
+	case callback := <-ran:
+		t.Fatalf(`%s OnShutdown callback ran before the request was drained`, callback)
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+
+	if status := <-responses; status != http.StatusOK {
+		t.Errorf(`request in flight : expected 200, got %d`, status)
+	}
+	if err := <-shutdown; err != nil {
+		t.Errorf(`Shutdown returned %s`, err.Error())
+	}
+	if first, second := <-ran, <-ran; first != `first` || second != `second` {
+		t.Errorf(`callbacks ran as %s then %s`, first, second)
+	}
+}
+
+func TestStats(t *testing.T) {
+
+	tests := []struct {
+		name             string
+		register         func(s *Server)
+		endpoints        int
+		resourceHandlers int
+	}{
+		{`empty`, func(s *Server) {}, 0, 0},
+		{`nested endpoints`, func(s *Server) {
+			s.NewEndpoint(`/users`, textResourceHandler(`GET`, `users`), textResourceHandler(`POST`, `created`))
+			s.NewEndpoint(`/users/me`, textResourceHandler(`GET`, `me`))
+		}, 2, 3},
+		{`host endpoints`, func(s *Server) {
+			s.NewEndpoint(`/users`, textResourceHandler(`GET`, `users`))
+			s.NewEndpointForHost(`api.example.com`, `/users`, textResourceHandler(`GET`, `users`), textResourceHandler(`DELETE`, `deleted`))
+		}, 2, 3},
+	}
+
+	for _, test := range tests {
+		s := NewServer(`/`, `:0`)
+		test.register(s)
+
+		stats := s.Stats()
+		if stats.Endpoints != test.endpoints || stats.ResourceHandlers != test.resourceHandlers {
+			t.Errorf(`%s : got %d endpoints and %d resource handlers, expected %d and %d`, test.name, stats.Endpoints, stats.ResourceHandlers, test.endpoints, test.resourceHandlers)
+		}
+		if stats.Uptime != 0 {
+			t.Errorf(`%s : uptime %s before serving`, test.name, stats.Uptime)
+		}
+	}
+}
+
+func TestResponseTransformers(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		accept string
+		status int
+		body   string
+	}{
+		{`transformed`, `application/json`, http.StatusOK, `{"data":{"name":"bob"}}`},
+		{`other content type`, `text/plain`, http.StatusOK, `bob`},
+		{`failing transformer`, `application/xml`, http.StatusInternalServerError, `Could not transform response body`},
+	}
+
+	s := NewServer(`/`, `:0`)
+	s.RegisterResponseTransformer(`application/json`, func(body []byte) ([]byte, error) {
+		return []byte(`{"data":` + string(body) + `}`), nil
+	})
+	s.RegisterResponseTransformer(`application/xml`, func(body []byte) ([]byte, error) {
+		return nil, errors.New(`malformed envelope`)
+	})
+	s.NewEndpoint(`/users/bob`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`application/json`, `text/plain`, `application/xml`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+		if *context.ContentTypeOut == `text/plain` {
+			return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(`bob`)}
+		}
+		return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(`{"name":"bob"}`)}
+	})})
+
+	for _, test := range tests {
+		recorder := serveTestRequest(s, `GET`, `/users/bob`, nil, map[string]string{`Accept`: test.accept})
+		if recorder.Code != test.status || recorder.Body.String() != test.body {
+			t.Errorf(`%s : got %d %q, expected %d %q`, test.name, recorder.Code, recorder.Body.String(), test.status, test.body)
+		}
+	}
+}
+
+func TestSlowRequestThreshold(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		threshold time.Duration
+		delay     time.Duration
+		warned    bool
+	}{
+		{`disabled`, 0, 20 * time.Millisecond, false},
+		{`fast request`, time.Minute, 0, false},
+		{`slow request`, 10 * time.Millisecond, 20 * time.Millisecond, true},
+	}
+
+	for _, test := range tests {
+		s := NewServer(`/`, `:0`)
+		s.SetSlowRequestThreshold(test.threshold)
+		s.NewEndpoint(`/report`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			time.Sleep(test.delay)
+			return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(`report`)}
+		})})
+
+		logged := captureLog(func() {
+			serveTestRequest(s, `GET`, `/report`, nil, nil)
+		})
+
+		if warned := strings.Contains(logged, `Slow request GET /report`); warned != test.warned {
+			t.Errorf(`%s : warned %t, expected %t`, test.name, warned, test.warned)
+		}
+	}
+}
+
+// Route variable type matching true or false only
+type boolRouteVariableType struct{}
+
+func (boolRouteVariableType) Matches(value string) bool {
+	return value == `true` || value == `false`
+}
+
+func TestRouteVariableKindMismatch(t *testing.T) {
+
+	s := NewServer(`/`, `:0`)
+	s.NewRouteVariableType(`digits`, digitsRouteVariableType{})
+	s.NewRouteVariableType(`bool`, boolRouteVariableType{})
+	s.NewEndpoint(`/users/{id:digits}`, textResourceHandler(`GET`, `user`))
+	s.NewEndpoint(`/flags/{enabled:bool}`, textResourceHandler(`GET`, `flag`))
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{`/users/42`, http.StatusOK, `user`},
+		{`/users/abc`, http.StatusBadRequest, `Route variable 'id' must be of kind 'digits', got 'abc'`},
+		{`/flags/true`, http.StatusOK, `flag`},
+		{`/flags/yes`, http.StatusBadRequest, `Route variable 'enabled' must be of kind 'bool', got 'yes'`},
+	}
+
+	for _, test := range tests {
+		recorder := serveTestRequest(s, `GET`, test.path, nil, nil)
+		if recorder.Code != test.status || recorder.Body.String() != test.body {
+			t.Errorf(`%s : got %d %q, expected %d %q`, test.path, recorder.Code, recorder.Body.String(), test.status, test.body)
+		}
+	}
+}
+
+func TestResultContentTypeOverride(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		result      ResourceHandlerResult
+		contentType string
+		body        string
+	}{
+		{`negotiated`, NewResult(http.StatusOK, map[string]string{`name`: `bob`}), `application/json`, `{"name":"bob"}`},
+		{`overridden body`, ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(`name,bob`), ContentType: `text/csv`}, `text/csv`, `name,bob`},
+		{`overridden payload codec`, ResourceHandlerResult{HttpStatus: http.StatusNotFound, Payload: map[string]string{`title`: `Not Found`}, ContentType: `application/problem+json`}, `application/problem+json`, `{"title":"Not Found"}`},
+	}
+
+	for _, test := range tests {
+		result := test.result
+		s := NewServer(`/`, `:0`)
+		s.NewEndpoint(`/users/bob`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`application/json`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			return result
+		})})
+
+		recorder := serveTestRequest(s, `GET`, `/users/bob`, nil, nil)
+		if recorder.Header().Get(`Content-Type`) != test.contentType || recorder.Body.String() != test.body {
+			t.Errorf(`%s : got %q as %q, expected %q as %q`, test.name, recorder.Body.String(), recorder.Header().Get(`Content-Type`), test.body, test.contentType)
+		}
+	}
+}
+
+// Body telling whether it was read
+type watchedBody struct {
+	io.Reader
+	read bool
+}
+
+func (b *watchedBody) Read(p []byte) (int, error) {
+	b.read = true
+	return b.Reader.Read(p)
+}
+
+func TestCatchAllRouteVariable(t *testing.T) {
+
+	s := NewServer(`/`, `:0`)
+	s.NewRouteVariableType(`any`, anyRouteVariableType{})
+	describe := ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+		tail, ok := context.CatchAll()
+		return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(fmt.Sprintf(`%t %q`, ok, tail))}
+	})
+	s.NewEndpoint(`/files/{path:*}`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/plain`}, Implementation: describe})
+	s.NewEndpoint(`/files/readme`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/plain`}, Implementation: describe})
+	s.NewEndpoint(`/users/{id:any}`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/plain`}, Implementation: describe})
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{`/files/a`, http.StatusOK, `true "a"`},
+		{`/files/docs/guide/intro.md`, http.StatusOK, `true "docs/guide/intro.md"`},
+		{`/files/readme`, http.StatusOK, `false ""`},
+		{`/users/42`, http.StatusOK, `false ""`},
+	}
+
+	for _, test := range tests {
+		recorder := serveTestRequest(s, `GET`, test.path, nil, nil)
+		if recorder.Code != test.status || recorder.Body.String() != test.body {
+			t.Errorf(`%s : got %d %s, expected %d %s`, test.path, recorder.Code, recorder.Body.String(), test.status, test.body)
+		}
+	}
+
+	if err := s.NewEndpoint(`/archives/{path:*}/download`, textResourceHandler(`GET`, `archive`)); err == nil {
+		t.Error(`a catch-all before the end of the route was accepted`)
+	}
+}
+
+func TestCoerceEmptyBodyTo204(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		coerce bool
+		result ResourceHandlerResult
+		status int
+	}{
+		{`empty 200`, true, ResourceHandlerResult{HttpStatus: http.StatusOK}, http.StatusNoContent},
+		{`empty buffer`, true, ResourceHandlerResult{HttpStatus: http.StatusOK, Body: new(bytes.Buffer)}, http.StatusNoContent},
+		{`200 with a body`, true, ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(`done`)}, http.StatusOK},
+		{`empty 201`, true, ResourceHandlerResult{HttpStatus: http.StatusCreated}, http.StatusCreated},
+		{`disabled`, false, ResourceHandlerResult{HttpStatus: http.StatusOK}, http.StatusOK},
+	}
+
+	for _, test := range tests {
+		result := test.result
+		s := NewServer(`/`, `:0`)
+		s.SetCoerceEmptyBodyTo204(test.coerce)
+		s.NewEndpoint(`/jobs`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			return result
+		})})
+
+		if recorder := serveTestRequest(s, `GET`, `/jobs`, nil, nil); recorder.Code != test.status {
+			t.Errorf(`%s : expected %d, got %d`, test.name, test.status, recorder.Code)
+		}
+	}
+}
+
+// Route variable type parsing digits into an int
+type intRouteVariableType struct {
+	digitsRouteVariableType
+}
+
+func (intRouteVariableType) Parse(value string) (interface{}, error) {
+	return strconv.Atoi(value)
+}
+
+// Route variable type parsing dates, matching any part shaped as one
+type dateRouteVariableType struct{}
+
+func (dateRouteVariableType) Matches(value string) bool {
+	return regexp.MustCompile(`^[0-9]{4}-[0-9]{2}-[0-9]{2}$`).MatchString(value)
+}
+
+func (dateRouteVariableType) Parse(value string) (interface{}, error) {
+	return time.Parse(`2006-01-02`, value)
+}
+
+func TestRouteVariableValues(t *testing.T) {
+
+	s := NewServer(`/`, `:0`)
+	s.NewRouteVariableType(`int`, intRouteVariableType{})
+	s.NewRouteVariableType(`date`, dateRouteVariableType{})
+	s.NewRouteVariableType(`any`, anyRouteVariableType{})
+	s.NewEndpoint(`/users/{id:int}/reports/{day:date}/{format:any}`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+		id, _ := context.RouteVariableValue(`id`)
+		day, _ := context.RouteVariableValue(`day`)
+		_, parsed := context.RouteVariableValue(`format`)
+		description := fmt.Sprintf(`%d %s %t`, id.(int)+1, day.(time.Time).Weekday(), parsed)
+		return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(description)}
+	})})
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{`/users/41/reports/2026-10-16/csv`, http.StatusOK, `42 Friday false`},
+		{`/users/41/reports/2026-13-45/csv`, http.StatusBadRequest, `Route variable 'day' must be of kind 'date', got '2026-13-45'`},
+	}
+
+	for _, test := range tests {
+		recorder := serveTestRequest(s, `GET`, test.path, nil, nil)
+		if recorder.Code != test.status || recorder.Body.String() != test.body {
+			t.Errorf(`%s : got %d %q, expected %d %q`, test.path, recorder.Code, recorder.Body.String(), test.status, test.body)
+		}
+	}
+}
+
+// Address of a port free at the time of the call
+func freeTestAddress(t *testing.T) string {
+
+	listener, err := net.Listen(`tcp`, `127.0.0.1:0`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	return listener.Addr().String()
+}
+
+func TestAddListener(t *testing.T) {
+
+	addresses := []string{freeTestAddress(t), freeTestAddress(t)}
+
+	s := NewServer(`/`, addresses[0])
+	s.AddListener(addresses[1])
+	s.NewEndpoint(`/hello`, textResourceHandler(`GET`, `hello`))
+	go s.ListenAndServe()
+	defer s.Shutdown(context.Background())
+
+	for _, address := range addresses {
+		request, _ := http.NewRequest(`GET`, `http://`+address+`/hello`, nil)
+		request.Header.Set(`Accept`, `text/plain`)
+
+		var response *http.Response
+		var err error
+		for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+			if response, err = http.DefaultClient.Do(request); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			t.Errorf(`%s : %s`, address, err.Error())
+			continue
+		}
+		body, _ := io.ReadAll(response.Body)
+		response.Body.Close()
+		if response.StatusCode != http.StatusOK || string(body) != `hello` {
+			t.Errorf(`%s : got %d %q`, address, response.StatusCode, body)
+		}
+	}
+}
+
+func TestAddListenerStopsAllListenersWhenOneFails(t *testing.T) {
+
+	taken, err := net.Listen(`tcp`, `127.0.0.1:0`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer taken.Close()
+
+	s := NewServer(`/`, freeTestAddress(t))
+	s.AddListener(taken.Addr().String())
+
+	served := make(chan error)
+	go func() {
+		served <- s.ListenAndServe()
+	}()
+
+	select {
+	case err := <-served:
+		if err == nil || err == http.ErrServerClosed {
+			t.Errorf(`ListenAndServe returned %v`, err)
+		}
+	case <-time.After(5 * time.Second):
+		s.Shutdown(context.Background())
+		t.Error(`ListenAndServe kept serving`)
+	}
+}
+
+func TestValidateReportsEveryRegistrationError(t *testing.T) {
+
+	s := NewServer(`/`, `:0`)
+	s.NewRouteVariableType(`digits`, digitsRouteVariableType{})
+	s.NewEndpoint(`/users/{id:digits}`, textResourceHandler(`GET`, `user`))
+
+	registrations := []struct {
+		name     string
+		register func() error
+		failed   bool
+	}{
+		{`valid endpoint`, func() error { return s.NewEndpoint(`/users`, textResourceHandler(`GET`, `users`)) }, false},
+		{`unknown route variable kind`, func() error { return s.NewEndpoint(`/orders/{id:uuid}`, textResourceHandler(`GET`, `order`)) }, true},
+		{`malformed route`, func() error { return s.NewEndpoint(`orders`, textResourceHandler(`GET`, `orders`)) }, true},
+		{`invalid host`, func() error { return s.NewEndpointForHost(`api.*.com`, `/users`, textResourceHandler(`GET`, `users`)) }, true},
+		{`duplicate route variable type`, func() error { return s.NewRouteVariableType(`digits`, digitsRouteVariableType{}) }, true},
+	}
+
+	expected := []error{}
+	for _, registration := range registrations {
+		err := registration.register()
+		if failed := err != nil; failed != registration.failed {
+			t.Errorf(`%s : failed %t, expected %t`, registration.name, failed, registration.failed)
+		}
+		if err != nil {
+			expected = append(expected, err)
+		}
+	}
+
+	errs := s.Validate()
+	if len(errs) != len(expected) {
+		t.Fatalf(`Validate returned %d errors, expected %d`, len(errs), len(expected))
+	}
+	for i := range errs {
+		if errs[i] != expected[i] {
+			t.Errorf(`error %d : %v, expected %v`, i, errs[i], expected[i])
+		}
+	}
+}
+
+func TestServersCoexistInOneProcess(t *testing.T) {
+
+	tests := []struct {
+		pattern string
+		path    string
+		text    string
+	}{
+		{`/`, `/hello`, `first`},
+		{`/`, `/hello`, `second`},
+		{`/api/`, `/api/hello`, `third`},
+	}
+
+	addresses := []string{}
+	for _, test := range tests {
+		address := freeTestAddress(t)
+		addresses = append(addresses, address)
+
+		s := NewServer(test.pattern, address)
+		s.NewEndpoint(test.path, textResourceHandler(`GET`, test.text))
+		go s.ListenAndServe()
+		defer s.Shutdown(context.Background())
+	}
+
+	for i, test := range tests {
+		request, _ := http.NewRequest(`GET`, `http://`+addresses[i]+test.path, nil)
+		request.Header.Set(`Accept`, `text/plain`)
+
+		var response *http.Response
+		var err error
+		for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+			if response, err = http.DefaultClient.Do(request); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			t.Errorf(`%s : %s`, test.text, err.Error())
+			continue
+		}
+		body, _ := io.ReadAll(response.Body)
+		response.Body.Close()
+		if response.StatusCode != http.StatusOK || string(body) != test.text {
+			t.Errorf(`%s : got %d %q`, test.text, response.StatusCode, body)
+		}
+	}
+}
+
+func TestPathRewriter(t *testing.T) {
+
+	tests := []struct {
+		name         string
+		path         string
+		status       int
+		originalPath string
+	}{
+		{`registered path`, `/v1/users`, http.StatusOK, `/v1/users`},
+		{`legacy alias`, `/v1/user`, http.StatusOK, `/v1/user`},
+		{`unknown path`, `/v1/groups`, http.StatusNotFound, ``},
+	}
+
+	s := NewServer(`/`, `:0`)
+	s.SetPathRewriter(func(path string) string {
+		if path == `/v1/user` {
+			return `/v1/users`
+		}
+		return path
+	})
+
+	var originalPath string
+	s.NewEndpoint(`/v1/users`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+		originalPath = context.OriginalPath
+		return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(`users`)}
+	})})
+
+	for _, test := range tests {
+		originalPath = ``
+		recorder := serveTestRequest(s, `GET`, test.path, nil, nil)
+		if recorder.Code != test.status || originalPath != test.originalPath {
+			t.Errorf(`%s : got %d with original path %q, expected %d with %q`, test.name, recorder.Code, originalPath, test.status, test.originalPath)
+		}
+	}
+}
+
+func TestCollapseSlashesKeepsServerPattern(t *testing.T) {
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{`/api/users`, http.StatusOK, `users`},
+		{`/api//users`, http.StatusOK, `users`},
+		{`//api/users`, http.StatusOK, `users`},
+		{`//other/app`, http.StatusNotFound, "404 page not found\n"},
+		{`/other//app`, http.StatusNotFound, "404 page not found\n"},
+	}
+
+	s := NewServer(`/api/`, `:0`)
+	s.SetCollapseSlashes(true)
+	s.NewEndpoint(`/api/users`, textResourceHandler(`GET`, `users`))
+
+	for _, test := range tests {
+		recorder := serveTestRequest(s.rootHandler(), `GET`, test.path, nil, nil)
+		if recorder.Code != test.status || recorder.Body.String() != test.body {
+			t.Errorf(`%s : got %d %q, expected %d %q`, test.path, recorder.Code, recorder.Body.String(), test.status, test.body)
+		}
+	}
+}
+
+func TestAllRejectedRouteVariablesAreReported(t *testing.T) {
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{`/users/42/flags/true`, http.StatusOK, `flag`},
+		{`/users/abc/flags/true`, http.StatusBadRequest, `Route variable 'id' must be of kind 'digits', got 'abc'`},
+		{`/users/42/flags/yes`, http.StatusBadRequest, `Route variable 'enabled' must be of kind 'bool', got 'yes'`},
+		{`/users/abc/flags/yes`, http.StatusBadRequest, `Route variable 'id' must be of kind 'digits', got 'abc', Route variable 'enabled' must be of kind 'bool', got 'yes'`},
+	}
+
+	s := NewServer(`/`, `:0`)
+	s.NewRouteVariableType(`digits`, digitsRouteVariableType{})
+	s.NewRouteVariableType(`bool`, boolRouteVariableType{})
+	s.NewEndpoint(`/users/{id:digits}/flags/{enabled:bool}`, textResourceHandler(`GET`, `flag`))
+
+	for _, test := range tests {
+		recorder := serveTestRequest(s, `GET`, test.path, nil, nil)
+		if recorder.Code != test.status || recorder.Body.String() != test.body {
+			t.Errorf(`%s : got %d %q, expected %d %q`, test.path, recorder.Code, recorder.Body.String(), test.status, test.body)
+		}
+	}
+
+	// As problem details, each rejected variable is an invalid param
+	s.EnableProblemJSON(true)
+	recorder := serveTestRequest(s, `GET`, `/users/abc/flags/yes`, nil, nil)
+	for _, invalidParam := range []string{`{"name":"id","reason":"must be of kind digits"}`, `{"name":"enabled","reason":"must be of kind bool"}`} {
+		if !strings.Contains(recorder.Body.String(), invalidParam) {
+			t.Errorf(`problem details : expected %s in %q`, invalidParam, recorder.Body.String())
+		}
+	}
+}
+
+func TestRequestDeadline(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		deadline time.Duration
+		work     time.Duration
+		err      error
+	}{
+		{`no deadline`, 0, 50 * time.Millisecond, nil},
+		{`work within the deadline`, time.Second, 10 * time.Millisecond, nil},
+		{`work past the deadline`, 20 * time.Millisecond, time.Second, context.DeadlineExceeded},
+	}
+
+	for _, test := range tests {
+		var hasDeadline bool
+		var err error
+		var requestContext context.Context
+
+		s := NewServer(`/`, `:0`)
+		s.SetRequestDeadline(test.deadline)
+		s.NewEndpoint(`/reports`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(func(handlerContext *ResourceHandlerContext) ResourceHandlerResult {
+			requestContext = handlerContext.Context
+			_, hasDeadline = requestContext.Deadline()
+			select {
+			case <-requestContext.Done():
+				err = requestContext.Err()
+			case <-time.After(test.work):
+			}
+			return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(`report`)}
+		})})
+
+		serveTestRequest(s, `GET`, `/reports`, nil, nil)
+
+		if hasDeadline != (test.deadline > 0) || err != test.err {
+			t.Errorf(`%s : deadline %t with %v, expected %v`, test.name, hasDeadline, err, test.err)
+		}
+		// Released once the request is served
+		if test.deadline > 0 && requestContext.Err() == nil {
+			t.Errorf(`%s : request context not canceled after the request`, test.name)
+		}
+	}
+}
+
+func TestCollapseSlashes(t *testing.T) {
+
+	tests := []struct {
+		name         string
+		collapse     bool
+		path         string
+		status       int
+		originalPath string
+	}{
+		{`clean path`, false, `/users/42`, http.StatusOK, `/users/42`},
+		{`repeated slashes kept`, false, `/users//42`, http.StatusMovedPermanently, ``},
+		{`clean path collapsing`, true, `/users/42`, http.StatusOK, `/users/42`},
+		{`repeated slashes`, true, `/users//42`, http.StatusOK, `/users//42`},
+		{`leading repeated slashes`, true, `//users/42`, http.StatusOK, `//users/42`},
+		{`many repeated slashes`, true, `/users///42`, http.StatusOK, `/users///42`},
+	}
+
+	for _, test := range tests {
+		var originalPath string
+
+		s := NewServer(`/`, `:0`)
+		s.SetCollapseSlashes(test.collapse)
+		s.NewRouteVariableType(`digits`, digitsRouteVariableType{})
+		s.NewEndpoint(`/users/{id:digits}`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			originalPath = context.OriginalPath
+			return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(`user`)}
+		})})
+
+		// Through the handler of the underlying http servers, as its mux redirects repeated slashes
+		recorder := serveTestRequest(s.rootHandler(), `GET`, test.path, nil, nil)
+		if recorder.Code != test.status || originalPath != test.originalPath {
+			t.Errorf(`%s : got %d with original path %q, expected %d with %q`, test.name, recorder.Code, originalPath, test.status, test.originalPath)
+		}
+	}
+}
+
+func TestRawResultSkipsTransformers(t *testing.T) {
+
+	tests := []struct {
+		name string
+		raw  bool
+		path string
+		body string
+	}{
+		{`transformed`, false, `/users/bob`, `{"data":{"name":"bob","email":"bob@example.com"}}`},
+		{`raw`, true, `/users/bob`, `{"name":"bob","email":"bob@example.com"}`},
+		{`fields selected then transformed`, false, `/users/bob?fields=name`, `{"data":{"name":"bob"}}`},
+		{`raw with fields`, true, `/users/bob?fields=name`, `{"name":"bob","email":"bob@example.com"}`},
+	}
+
+	for _, test := range tests {
+		s := NewServer(`/`, `:0`)
+		s.RegisterResponseTransformer(`application/json`, func(body []byte) ([]byte, error) {
+			return []byte(`{"data":` + string(body) + `}`), nil
+		})
+		s.NewEndpoint(`/users/bob`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`application/json`}, SparseFieldsets: true, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(`{"name":"bob","email":"bob@example.com"}`), Raw: test.raw}
+		})})
+
+		recorder := serveTestRequest(s, `GET`, test.path, nil, nil)
+		if recorder.Code != http.StatusOK || recorder.Body.String() != test.body {
+			t.Errorf(`%s : got %d %q, expected %q`, test.name, recorder.Code, recorder.Body.String(), test.body)
+		}
+	}
+}
+
+func TestLogMatchedRouteKeepsTheRawPathOutOfTheLogs(t *testing.T) {
+
+	s := NewServer(`/`, `:0`)
+	s.NewRouteVariableType(`any`, anyRouteVariableType{})
+	s.NewRouteVariableType(`digits`, digitsRouteVariableType{})
+	s.NewEndpoint(`/users/{email:any}`, textResourceHandler(`GET`, `user`))
+	s.NewEndpoint(`/orders/{id:digits}`, textResourceHandler(`GET`, `order`))
+	s.SetLogMatchedRoute(true)
+	s.DebugEnableLogRequestDump(true)
+	s.DebugEnableLogResponseBody(1024)
+
+	tests := []struct {
+		path   string
+		status int
+		logged string
+	}{
+		{`/users/jane@example.com`, http.StatusOK, `/users/{email:any}`},
+		{`/orders/jane@example.com`, http.StatusBadRequest, `'id' must be of kind digits`},
+		{`/unknown/jane@example.com`, http.StatusNotFound, `[REDACTED]`},
+	}
+
+	for _, test := range tests {
+		var status int
+		logged := captureLog(func() {
+			status = serveTestRequest(s, `GET`, test.path, nil, nil).Code
+		})
+
+		if status != test.status {
+			t.Errorf(`%s : expected %d, got %d`, test.path, test.status, status)
+		}
+		if !strings.Contains(logged, test.logged) {
+			t.Errorf(`%s : %q is not logged`, test.path, test.logged)
+		}
+		if strings.Contains(logged, `jane`) {
+			t.Errorf(`%s : the raw path is logged`, test.path)
+		}
+	}
+}
+
+func TestMaxQueryParameters(t *testing.T) {
+
+	s := NewServer(`/`, `:0`)
+	s.SetMaxQueryParameters(3)
+	s.NewEndpoint(`/items`, ResourceHandler{Method: `POST`, ContentTypeIn: []string{`text/plain`}, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+		return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(`created`)}
+	})})
+
+	tests := []struct {
+		query  string
+		status int
+		read   bool
+	}{
+		{``, http.StatusOK, true},
+		{`?a=1&b=2&c=3`, http.StatusOK, true},
+		{`?a=1&b=2&c=3&d=4`, http.StatusBadRequest, false},
+		{`?` + strings.Repeat(`x=1&`, 5000) + `y=2`, http.StatusBadRequest, false},
+	}
+
+	for _, test := range tests {
+		body := &watchedBody{Reader: strings.NewReader(`item`)}
+		recorder := serveTestRequest(s, `POST`, `/items`+test.query, body, map[string]string{`Content-Type`: `text/plain`})
+
+		if recorder.Code != test.status {
+			t.Errorf(`%d parameters : expected %d, got %d`, strings.Count(test.query, `=`), test.status, recorder.Code)
+		}
+		if body.read != test.read {
+			t.Errorf(`%d parameters : body read %t, expected %t`, strings.Count(test.query, `=`), body.read, test.read)
+		}
+	}
+}
+
+func TestDefaultRequestContentType(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		defaultType string
+		contentType string
+		body        io.Reader
+		status      int
+		decoded     string
+	}{
+		{`json without content type`, `application/json`, ``, strings.NewReader(`{"name":"bob"}`), http.StatusCreated, `bob`},
+		{`body of unknown length`, `application/json`, ``, io.MultiReader(strings.NewReader(`{"name":"bob"}`)), http.StatusCreated, `bob`},
+		{`explicit content type kept`, `application/xml`, `application/json`, strings.NewReader(`{"name":"bob"}`), http.StatusCreated, `bob`},
+		{`no default`, ``, ``, strings.NewReader(`{"name":"bob"}`), http.StatusBadRequest, ``},
+		{`no body`, `application/json`, ``, nil, http.StatusBadRequest, ``},
+	}
+
+	for _, test := range tests {
+		var decoded string
+
+		s := NewServer(`/`, `:0`)
+		s.SetDefaultRequestContentType(test.defaultType)
+		s.NewEndpoint(`/users`, ResourceHandler{Method: `POST`, ContentTypeIn: []string{`application/json`}, ContentTypeOut: []string{`text/plain`}, Implementation: ErrorHandlerFunc(func(context *ResourceHandlerContext) (ResourceHandlerResult, error) {
+			var user codecTestUser
+			if err := context.Decode(&user); err != nil {
+				return ResourceHandlerResult{}, err
+			}
+			decoded = user.Name
+			return ResourceHandlerResult{HttpStatus: http.StatusCreated}, nil
+		})})
+
+		recorder := serveTestRequest(s, `POST`, `/users`, test.body, map[string]string{`Content-Type`: test.contentType})
+		if recorder.Code != test.status || decoded != test.decoded {
+			t.Errorf(`%s : got %d decoding %q, expected %d decoding %q`, test.name, recorder.Code, decoded, test.status, test.decoded)
+		}
+	}
+}
+
+func TestConnStateHook(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		serve func(s *Server) (*http.Client, string)
+	}{
+		{`tcp`, func(s *Server) (*http.Client, string) {
+			address := freeTestAddress(t)
+			s.address = address
+			go s.ListenAndServe()
+			return &http.Client{Transport: &http.Transport{}}, `http://` + address
+		}},
+		{`unix socket`, func(s *Server) (*http.Client, string) {
+			path := filepath.Join(unixSocketTestDir(t), `gorip.sock`)
+			go s.ListenAndServeUnix(path)
+			return unixTestClient(path), `http://unix`
+		}},
+	}
+
+	for _, test := range tests {
+		states := make(chan http.ConnState, 16)
+
+		s := NewServer(`/`, `:0`)
+		s.SetConnStateHook(func(conn net.Conn, state http.ConnState) {
+			states <- state
+		})
+		s.NewEndpoint(`/hello`, textResourceHandler(`GET`, `hello`))
+		client, url := test.serve(s)
+		client.Transport.(*http.Transport).DisableKeepAlives = true
+
+		request, _ := http.NewRequest(`GET`, url+`/hello`, nil)
+		request.Header.Set(`Accept`, `text/plain`)
+
+		var response *http.Response
+		var err error
+		for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+			if response, err = client.Do(request); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			t.Errorf(`%s : %s`, test.name, err.Error())
+			s.Shutdown(context.Background())
+			continue
+		}
+		io.ReadAll(response.Body)
+		response.Body.Close()
+
+		// New, then active while serving, then closed as keep alives are disabled
+		observed := []http.ConnState{}
+		timeout := time.After(time.Second)
+	collect:
+		for {
+			select {
+			case state := <-states:
+				observed = append(observed, state)
+				if state == http.StateClosed {
+					break collect
+				}
+			case <-timeout:
+				break collect
+			}
+		}
+		s.Shutdown(context.Background())
+
+		if fmt.Sprint(observed) != fmt.Sprint([]http.ConnState{http.StateNew, http.StateActive, http.StateClosed}) {
+			t.Errorf(`%s : observed %v`, test.name, observed)
+		}
+	}
+}
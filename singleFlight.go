@@ -0,0 +1,145 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Single-flight : identical concurrent GET requests share one execution of the handler.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+type singleFlightCall struct {
+	done      chan struct{}
+	completed bool // false if the first caller panicked
+	result    ResourceHandlerResult
+	body      []byte // snapshot of the result body, each caller getting its own copy
+}
+
+// Coalesces calls under the same key, only the first one running while the others wait for its result
+type singleFlightGroup struct {
+	mutex sync.Mutex
+	calls map[string]*singleFlightCall
+}
+
+// Identical requests share a key : same URL, negotiated content type and credentials
+func singleFlightKey(request *http.Request, contentTypeOut string) string {
+	return strings.Join([]string{request.Host + request.URL.RequestURI(), contentTypeOut, request.Header.Get(`Authorization`), request.Header.Get(`Cookie`)}, "\n")
+}
+
+func newSingleFlightGroup() *singleFlightGroup {
+	return &singleFlightGroup{calls: make(map[string]*singleFlightCall)}
+}
+
+func (g *singleFlightGroup) do(key string, execute func() ResourceHandlerResult) ResourceHandlerResult {
+
+	g.mutex.Lock()
+	call, inFlight := g.calls[key]
+	if !inFlight {
+		call = &singleFlightCall{done: make(chan struct{})}
+		g.calls[key] = call
+	}
+	g.mutex.Unlock()
+
+	if inFlight {
+		<-call.done
+		// No result to share, or a stream written by the handler itself which can not be shared
+		if !call.completed || call.result.Stream != nil {
+			return execute()
+		}
+		return call.copyResult()
+	}
+
+	// Deferred so that the waiting callers are released even if execute panics
+	defer func() {
+		g.mutex.Lock()
+		delete(g.calls, key)
+		g.mutex.Unlock()
+		close(call.done)
+	}()
+
+	call.result = execute()
+	if call.result.Body != nil {
+		call.body = append([]byte{}, call.result.Body.Bytes()...)
+	}
+	call.completed = true
+
+	if call.result.Stream != nil {
+		return call.result
+	}
+
+	return call.copyResult()
+}
+
+// Copy of the result whose body and header can be consumed and changed independently
+func (c *singleFlightCall) copyResult() ResourceHandlerResult {
+
+	result := c.result
+	if c.result.Body != nil {
+		result.Body = bytes.NewBuffer(append([]byte{}, c.body...))
+	}
+	result.Header = c.result.Header.Clone()
+
+	return result
+}
+
+// Runs the GET handler of a registered route once for identical concurrent requests, by URL, negotiated content type
+// and credentials ( Authorization and Cookie headers ), every caller getting the same response ; the endpoint middlewares
+// still run for every caller, only the implementation is shared ; meant for expensive cacheable resources not depending on other request headers
+func (s *Server) EnableSingleFlight(route string) error {
+	return s.recordRegistrationError(s.enableSingleFlight(route))
+}
+
+func (s *Server) enableSingleFlight(route string) error {
+
+	routers := []*router{s.router}
+	for _, hr := range s.hostRouters {
+		routers = append(routers, hr.router)
+	}
+
+	found := false
+	for _, r := range routers {
+		node, err := r.FindNodeByPattern(route)
+		if err == nil {
+			node.GetEndpoint().singleFlight = true
+			found = true
+		}
+	}
+
+	if !found {
+		return errors.New(fmt.Sprintf(`Route %s is not registered`, route))
+	}
+
+	if s.singleFlight == nil {
+		s.singleFlight = newSingleFlightGroup()
+	}
+
+	Flog(FLOG_TYPE_ACTION, fmt.Sprintf("Single-flight enabled on %s\n", TermColorEscape(route, TERM_COLOR_BLUE)))
+
+	return nil
+}
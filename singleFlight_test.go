@@ -0,0 +1,190 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Single-flight tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSingleFlightSharesTheResult(t *testing.T) {
+
+	var runs int32
+	s := NewServer(`/`, `:0`)
+	s.NewEndpoint(`/slow`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+		atomic.AddInt32(&runs, 1)
+		time.Sleep(100 * time.Millisecond)
+		return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(`shared`)}
+	})})
+	if err := s.EnableSingleFlight(`/slow`); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.EnableSingleFlight(`/unknown`); err == nil {
+		t.Fatal(`expected an error for an unregistered route`)
+	}
+
+	var wg sync.WaitGroup
+	bodies := make([]string, 10)
+	for i := range bodies {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			bodies[i] = serveTestRequest(s, `GET`, `/slow`, nil, nil).Body.String()
+		}(i)
+	}
+	wg.Wait()
+
+	if runs != 1 {
+		t.Errorf(`handler ran %d times, expected once`, runs)
+	}
+	for _, body := range bodies {
+		if body != `shared` {
+			t.Errorf(`unexpected body %q`, body)
+		}
+	}
+}
+
+func TestSingleFlightRunsMiddlewaresForEveryCaller(t *testing.T) {
+
+	release := make(chan struct{})
+	s := NewServer(`/`, `:0`)
+	s.NewEndpoint(`/private`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+		<-release
+		return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(`secret`)}
+	})})
+	s.UseOnEndpoint(`/private`, func(next ResourceHandlerImplementation) ResourceHandlerImplementation {
+		return ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			if context.Header.Get(`Authorization`) != `token` {
+				return ResourceHandlerResult{HttpStatus: http.StatusUnauthorized}
+			}
+			return next.Execute(context)
+		})
+	})
+	s.EnableSingleFlight(`/private`)
+
+	authorized := make(chan *httptest.ResponseRecorder)
+	go func() {
+		authorized <- serveTestRequest(s, `GET`, `/private`, nil, map[string]string{`Authorization`: `token`})
+	}()
+
+	// The authorized request is in flight
+	for {
+		s.singleFlight.mutex.Lock()
+		inFlight := len(s.singleFlight.calls)
+		s.singleFlight.mutex.Unlock()
+		if inFlight == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	anonymous := serveTestRequest(s, `GET`, `/private`, nil, nil)
+	close(release)
+
+	if anonymous.Code != http.StatusUnauthorized {
+		t.Errorf(`anonymous request : expected 401, got %d %q`, anonymous.Code, anonymous.Body.String())
+	}
+	if recorder := <-authorized; recorder.Code != http.StatusOK || recorder.Body.String() != `secret` {
+		t.Errorf(`authorized request : got %d %q`, recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestSingleFlightKey(t *testing.T) {
+
+	newRequest := func(target string, header map[string]string) *http.Request {
+		request := httptest.NewRequest(`GET`, target, nil)
+		for name, value := range header {
+			request.Header.Set(name, value)
+		}
+		return request
+	}
+
+	base := singleFlightKey(newRequest(`/items?page=1`, map[string]string{`Authorization`: `a`}), `application/json`)
+
+	tests := []struct {
+		name        string
+		request     *http.Request
+		contentType string
+		shared      bool
+	}{
+		{`identical`, newRequest(`/items?page=1`, map[string]string{`Authorization`: `a`}), `application/json`, true},
+		{`query`, newRequest(`/items?page=2`, map[string]string{`Authorization`: `a`}), `application/json`, false},
+		{`content type`, newRequest(`/items?page=1`, map[string]string{`Authorization`: `a`}), `application/xml`, false},
+		{`authorization`, newRequest(`/items?page=1`, map[string]string{`Authorization`: `b`}), `application/json`, false},
+		{`anonymous`, newRequest(`/items?page=1`, nil), `application/json`, false},
+		{`cookie`, newRequest(`/items?page=1`, map[string]string{`Authorization`: `a`, `Cookie`: `session=1`}), `application/json`, false},
+	}
+
+	for _, test := range tests {
+		if shared := singleFlightKey(test.request, test.contentType) == base; shared != test.shared {
+			t.Errorf(`%s : shared %t, expected %t`, test.name, shared, test.shared)
+		}
+	}
+}
+
+func TestSingleFlightReleasesWaitersWhenExecutePanics(t *testing.T) {
+
+	g := newSingleFlightGroup()
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	go func() {
+		defer func() { recover() }()
+		g.do(`key`, func() ResourceHandlerResult {
+			close(started)
+			<-release
+			panic(`handler failure`)
+		})
+	}()
+	<-started
+
+	waiter := make(chan ResourceHandlerResult)
+	go func() {
+		waiter <- g.do(`key`, func() ResourceHandlerResult {
+			return ResourceHandlerResult{HttpStatus: http.StatusOK}
+		})
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+
+	select {
+	case result := <-waiter:
+		if result.HttpStatus != http.StatusOK {
+			t.Errorf(`waiter : expected its own result, got %d`, result.HttpStatus)
+		}
+	case <-time.After(time.Second):
+		t.Fatal(`waiter is still blocked`)
+	}
+
+	if len(g.calls) != 0 {
+		t.Errorf(`key is still in flight`)
+	}
+}
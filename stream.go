@@ -0,0 +1,88 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Streaming responses : the body is written progressively, optionally followed by trailers.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Given to ResourceHandlerResult.Stream to write the response body progressively
+type ResponseStream struct {
+	writer   http.ResponseWriter
+	trailers map[string]bool
+}
+
+func newResponseStream(writer http.ResponseWriter, trailers []string) *ResponseStream {
+
+	stream := &ResponseStream{writer: writer, trailers: make(map[string]bool)}
+	for _, t := range trailers {
+		stream.trailers[http.CanonicalHeaderKey(t)] = true
+	}
+
+	return stream
+}
+
+func (r *ResponseStream) Write(p []byte) (int, error) {
+	return r.writer.Write(p)
+}
+
+// Sends what was written so far to the client, if the connection supports it
+func (r *ResponseStream) Flush() {
+	if flusher, ok := r.writer.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Sets the value of a trailer, the name must have been declared in ResourceHandlerResult.Trailers
+func (r *ResponseStream) SetTrailer(name string, value string) error {
+
+	if !r.trailers[http.CanonicalHeaderKey(name)] {
+		return errors.New(fmt.Sprintf(`Trailer %s was not declared`, name))
+	}
+
+	r.writer.Header().Set(name, value)
+
+	return nil
+}
+
+// Writes the headers then lets the result stream its body, trailers are sent once the stream returns
+func (s *Server) streamResourceResult(writer http.ResponseWriter, result *ResourceHandlerResult, contentType string, requestId string) {
+
+	if len(result.Trailers) > 0 {
+		writer.Header().Set(`Trailer`, strings.Join(result.Trailers, `, `))
+	}
+
+	writer.Header().Set(`Content-Type`, contentType)
+	writer.WriteHeader(result.HttpStatus)
+
+	err := result.Stream(newResponseStream(writer, result.Trailers))
+	if err != nil {
+		Flog(FLOG_TYPE_ERROR, fmt.Sprintf("%s Error while streaming the body %s", requestId, err.Error()))
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Streaming response tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStreamWithTrailers(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		trailers  []string
+		trailer   string
+		setFailed bool
+		received  string
+	}{
+		{`declared trailer`, []string{`X-Checksum`}, `x-checksum`, false, `abc123`},
+		{`undeclared trailer`, nil, `X-Checksum`, true, ``},
+		{`other declared trailer`, []string{`X-Row-Count`}, `X-Checksum`, true, ``},
+	}
+
+	for _, test := range tests {
+		var setErr error
+		s := NewServer(`/`, `:0`)
+		s.NewEndpoint(`/export`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/csv`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			return ResourceHandlerResult{HttpStatus: http.StatusOK, Trailers: test.trailers, Stream: func(stream *ResponseStream) error {
+				io.WriteString(stream, "id,name\n")
+				stream.Flush()
+				io.WriteString(stream, "1,bob\n")
+				setErr = stream.SetTrailer(test.trailer, `abc123`)
+				return nil
+			}}
+		})})
+		server := httptest.NewServer(s)
+
+		request, _ := http.NewRequest(`GET`, server.URL+`/export`, nil)
+		request.Header.Set(`Accept`, `text/csv`)
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(response.Body)
+		response.Body.Close()
+		server.Close()
+
+		if response.StatusCode != http.StatusOK || string(body) != "id,name\n1,bob\n" {
+			t.Errorf(`%s : got %d %q`, test.name, response.StatusCode, body)
+		}
+		if setFailed := setErr != nil; setFailed != test.setFailed {
+			t.Errorf(`%s : SetTrailer failed %t, expected %t`, test.name, setFailed, test.setFailed)
+		}
+		if received := response.Trailer.Get(`X-Checksum`); received != test.received {
+			t.Errorf(`%s : trailer %q, expected %q`, test.name, received, test.received)
+		}
+	}
+}
+
+func TestStreamPastWriteTimeout(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		extend   bool
+		complete bool
+	}{
+		{`deadline extended`, true, true},
+		{`deadline not extended`, false, false},
+	}
+
+	for _, test := range tests {
+		var extendErr error
+		s := NewServer(`/`, `:0`)
+		s.NewEndpoint(`/events`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			return ResourceHandlerResult{HttpStatus: http.StatusOK, Stream: func(stream *ResponseStream) error {
+				for i := 0; i < 5; i++ {
+					if test.extend {
+						if err := context.ExtendWriteDeadline(200 * time.Millisecond); err != nil {
+							extendErr = err
+						}
+					}
+					io.WriteString(stream, "tick\n")
+					stream.Flush()
+					time.Sleep(60 * time.Millisecond)
+				}
+				return nil
+			}}
+		})})
+		server := httptest.NewUnstartedServer(s)
+		server.Config.WriteTimeout = 100 * time.Millisecond
+		server.Start()
+
+		request, _ := http.NewRequest(`GET`, server.URL+`/events`, nil)
+		request.Header.Set(`Accept`, `text/plain`)
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			server.Close()
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(response.Body)
+		response.Body.Close()
+		server.Close()
+
+		if extendErr != nil {
+			t.Errorf(`%s : %s`, test.name, extendErr.Error())
+		}
+		if complete := string(body) == strings.Repeat("tick\n", 5); complete != test.complete {
+			t.Errorf(`%s : received %q, complete %t expected %t`, test.name, body, complete, test.complete)
+		}
+	}
+}
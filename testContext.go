@@ -0,0 +1,98 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Builds resource handler contexts to unit test implementations without a server.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	gocontext "context"
+	"net/http"
+)
+
+type TestContextOption func(context *ResourceHandlerContext)
+
+// Creates a context as the server would provide it to ResourceHandlerImplementation.Execute, e.g. :
+//
+//	context := gorip.NewTestContext(gorip.WithRouteVariable("user_id", "1"), gorip.WithQueryParameter("foo", "George"))
+//	result := GetUserResourceHandlerImpl{}.Execute(context)
+func NewTestContext(opts ...TestContextOption) *ResourceHandlerContext {
+
+	context := &ResourceHandlerContext{}
+	context.RouteVariables = make(map[string]string)
+	context.QueryParameters = make(map[string]string)
+	context.Header = make(http.Header)
+	context.Body = new(bytes.Buffer)
+	context.BodyReader = bytes.NewReader(nil)
+	context.Context = gocontext.Background()
+
+	for _, opt := range opts {
+		opt(context)
+	}
+
+	return context
+}
+
+func WithRouteVariable(name string, value string) TestContextOption {
+	return func(context *ResourceHandlerContext) {
+		context.RouteVariables[name] = value
+	}
+}
+
+func WithQueryParameter(name string, value string) TestContextOption {
+	return func(context *ResourceHandlerContext) {
+		context.QueryParameters[name] = value
+	}
+}
+
+func WithHeader(name string, value string) TestContextOption {
+	return func(context *ResourceHandlerContext) {
+		context.Header.Add(name, value)
+	}
+}
+
+func WithBody(body []byte) TestContextOption {
+	return func(context *ResourceHandlerContext) {
+		context.Body = bytes.NewBuffer(body)
+		context.BodyReader = bytes.NewReader(body)
+	}
+}
+
+func WithContentTypeIn(contentType string) TestContextOption {
+	return func(context *ResourceHandlerContext) {
+		context.ContentTypeIn = &contentType
+	}
+}
+
+func WithContentTypeOut(contentType string) TestContextOption {
+	return func(context *ResourceHandlerContext) {
+		context.ContentTypeOut = &contentType
+	}
+}
+
+func WithValue(key string, value interface{}) TestContextOption {
+	return func(context *ResourceHandlerContext) {
+		context.Set(key, value)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Test context tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// Implementation describing what it was given
+var describeContextImplementation = ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+
+	var body map[string]string
+	if context.ContentTypeIn != nil {
+		if err := context.Decode(&body); err != nil {
+			return errorResult(err)
+		}
+	}
+	tenant, _ := context.Get(`tenant`)
+
+	description := fmt.Sprintf(`user=%s foo=%s auth=%s out=%s name=%s tenant=%v`, context.RouteVariables[`user_id`], context.QueryParameters[`foo`], context.Header.Get(`Authorization`), context.ResponseContentType(), body[`name`], tenant)
+
+	return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(description)}
+})
+
+func TestNewTestContext(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		options     []TestContextOption
+		status      int
+		description string
+	}{
+		{`empty`, nil, http.StatusOK, `user= foo= auth= out= name= tenant=<nil>`},
+		{
+			`route variable, query parameter and header`,
+			[]TestContextOption{WithRouteVariable(`user_id`, `1`), WithQueryParameter(`foo`, `George`), WithHeader(`Authorization`, `Bearer token`)},
+			http.StatusOK,
+			`user=1 foo=George auth=Bearer token out= name= tenant=<nil>`,
+		},
+		{
+			`decoded body`,
+			[]TestContextOption{WithContentTypeIn(`application/json`), WithContentTypeOut(`text/plain`), WithBody([]byte(`{"name":"bob"}`))},
+			http.StatusOK,
+			`user= foo= auth= out=text/plain name=bob tenant=<nil>`,
+		},
+		{`malformed body`, []TestContextOption{WithContentTypeIn(`application/json`), WithBody([]byte(`{bad`))}, http.StatusBadRequest, ``},
+		{`value`, []TestContextOption{WithValue(`tenant`, `acme`)}, http.StatusOK, `user= foo= auth= out= name= tenant=acme`},
+	}
+
+	for _, test := range tests {
+		result := describeContextImplementation.Execute(NewTestContext(test.options...))
+		if result.HttpStatus != test.status {
+			t.Errorf(`%s : expected %d, got %d`, test.name, test.status, result.HttpStatus)
+		}
+		if test.status == http.StatusOK && result.Body.String() != test.description {
+			t.Errorf(`%s : got %q, expected %q`, test.name, result.Body.String(), test.description)
+		}
+	}
+}
+
+func TestNewTestContextHasABackgroundContext(t *testing.T) {
+
+	context := NewTestContext()
+	if context.Context == nil || context.Context.Err() != nil {
+		t.Error(`test context has no live request context`)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Serving on a Unix domain socket instead of TCP.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+)
+
+// Serves on a Unix domain socket, the socket file is removed when the server shuts down
+// A stale socket file left by a previous process is replaced, a socket still in use is an error
+func (s *Server) ListenAndServeUnix(socketPath string) error {
+
+	err := s.runOnStartCallbacks()
+	if err != nil {
+		return err
+	}
+
+	err = removeStaleUnixSocket(socketPath)
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen(`unix`, socketPath)
+	if err != nil {
+		return err
+	}
+
+	Flog(FLOG_TYPE_ACTION, fmt.Sprintf("goRip is Ready, listening to unix socket %s\n", TermColorEscape(socketPath, TERM_COLOR_BLUE)))
+
+	httpServer := &http.Server{Handler: s.rootHandler(), ConnState: s.connStateHook}
+
+	s.httpServerMutex.Lock()
+	s.httpServers = append(s.httpServers, httpServer)
+	s.startTime = time.Now()
+	s.httpServerMutex.Unlock()
+
+	// Closing the listener on shutdown also unlinks the socket file
+	return httpServer.Serve(listener)
+}
+
+// Only a socket file is ever removed, any other file at that path is an error
+func removeStaleUnixSocket(socketPath string) error {
+
+	info, err := os.Lstat(socketPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if info.Mode()&os.ModeSocket == 0 {
+		return errors.New(fmt.Sprintf(`%s exists and is not a unix socket`, socketPath))
+	}
+
+	conn, err := net.Dial(`unix`, socketPath)
+	if err == nil {
+		conn.Close()
+		return errors.New(fmt.Sprintf(`Unix socket %s is already in use`, socketPath))
+	}
+
+	Flog(FLOG_TYPE_WARNING, fmt.Sprintf("Removing stale unix socket %s", socketPath))
+
+	return os.Remove(socketPath)
+}
@@ -0,0 +1,134 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Unix domain socket tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Short directory, socket paths being limited in length
+func unixSocketTestDir(t *testing.T) string {
+
+	dir, err := os.MkdirTemp(``, `gorip`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	return dir
+}
+
+// Client dialing the unix socket at path, whatever the request host
+func unixTestClient(path string) *http.Client {
+	return &http.Client{Transport: &http.Transport{DialContext: func(ctx context.Context, network string, address string) (net.Conn, error) {
+		return net.Dial(`unix`, path)
+	}}}
+}
+
+func TestRemoveStaleUnixSocket(t *testing.T) {
+
+	dir := unixSocketTestDir(t)
+
+	tests := []struct {
+		name    string
+		prepare func(path string) func()
+		valid   bool
+		removed bool
+	}{
+		{`missing`, func(path string) func() { return func() {} }, true, true},
+		{`regular file`, func(path string) func() {
+			os.WriteFile(path, []byte(`data`), 0600)
+			return func() {}
+		}, false, false},
+		{`stale socket`, func(path string) func() {
+			listener, _ := net.Listen(`unix`, path)
+			listener.(*net.UnixListener).SetUnlinkOnClose(false)
+			listener.Close()
+			return func() {}
+		}, true, true},
+		{`socket in use`, func(path string) func() {
+			listener, _ := net.Listen(`unix`, path)
+			return func() { listener.Close() }
+		}, false, false},
+	}
+
+	for _, test := range tests {
+		path := filepath.Join(dir, test.name)
+		cleanup := test.prepare(path)
+
+		err := removeStaleUnixSocket(path)
+		if test.valid && err != nil {
+			t.Errorf(`%s : unexpected error %s`, test.name, err.Error())
+		}
+		if !test.valid && err == nil {
+			t.Errorf(`%s : expected an error`, test.name)
+		}
+		if _, statErr := os.Lstat(path); os.IsNotExist(statErr) != test.removed {
+			t.Errorf(`%s : removed %t, expected %t`, test.name, os.IsNotExist(statErr), test.removed)
+		}
+
+		cleanup()
+	}
+}
+
+func TestListenAndServeUnix(t *testing.T) {
+
+	path := filepath.Join(unixSocketTestDir(t), `server.sock`)
+
+	s := NewServer(`/`, `:0`)
+	s.NewEndpoint(`/hello`, textResourceHandler(`GET`, `unix`))
+	go s.ListenAndServeUnix(path)
+	defer s.Shutdown(context.Background())
+
+	client := unixTestClient(path)
+
+	request, _ := http.NewRequest(`GET`, `http://unix/hello`, nil)
+	request.Header.Set(`Accept`, `text/plain`)
+
+	var response *http.Response
+	var err error
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if response, err = client.Do(request); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+
+	body, _ := io.ReadAll(response.Body)
+	if response.StatusCode != http.StatusOK || string(body) != `unix` {
+		t.Errorf(`got %d %q`, response.StatusCode, body)
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Format validators helpers, on top of goformatvalidation.Validator.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"fmt"
+	"github.com/sigu-399/goformatvalidation"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Validator passing only if all of its validators pass
+type allValidator struct {
+	validators []goformatvalidation.Validator
+}
+
+// Combines validators into one, e.g. for a single FormatValidator field
+// Its error message lists all of the combined validators messages
+func NewAllValidator(validators ...goformatvalidation.Validator) goformatvalidation.Validator {
+	return &allValidator{validators: validators}
+}
+
+func (v *allValidator) IsValid(value string) bool {
+	return findFailingValidator(v.validators, value) == nil
+}
+
+func (v *allValidator) GetErrorMessage() string {
+
+	messages := []string{}
+	for _, validator := range v.validators {
+		messages = append(messages, validator.GetErrorMessage())
+	}
+
+	return strings.Join(messages, `, `)
+}
+
+// Runs validators in order, returns the first one rejecting the value or nil
+func findFailingValidator(validators []goformatvalidation.Validator, value string) goformatvalidation.Validator {
+
+	for _, validator := range validators {
+		if validator != nil && !validator.IsValid(value) {
+			return validator
+		}
+	}
+
+	return nil
+}
+
+// Validator based on a simple check function
+type funcValidator struct {
+	isValid      func(value string) bool
+	errorMessage string
+}
+
+func (v *funcValidator) IsValid(value string) bool {
+	return v.isValid(value)
+}
+
+func (v *funcValidator) GetErrorMessage() string {
+	return v.errorMessage
+}
+
+var uuidRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Validates a single email address, without display name
+func NewEmailValidator() goformatvalidation.Validator {
+	return &funcValidator{
+		isValid: func(value string) bool {
+			address, err := mail.ParseAddress(value)
+			return err == nil && address.Name == `` && address.Address == value
+		},
+		errorMessage: `must be a valid email address`,
+	}
+}
+
+// Validates an absolute URL, with a scheme and a host
+func NewURLValidator() goformatvalidation.Validator {
+	return &funcValidator{
+		isValid: func(value string) bool {
+			u, err := url.ParseRequestURI(value)
+			return err == nil && u.Scheme != `` && u.Host != ``
+		},
+		errorMessage: `must be a valid absolute URL`,
+	}
+}
+
+// Validates a UUID in its canonical textual form
+func NewUUIDValidator() goformatvalidation.Validator {
+	return &funcValidator{
+		isValid:      uuidRegexp.MatchString,
+		errorMessage: `must be a valid UUID`,
+	}
+}
+
+// Validates that the value is not empty nor only made of spaces
+func NewNonEmptyValidator() goformatvalidation.Validator {
+	return &funcValidator{
+		isValid: func(value string) bool {
+			return strings.TrimSpace(value) != ``
+		},
+		errorMessage: `must not be empty`,
+	}
+}
+
+// Validates a number within min and max, both inclusive
+func NewRangeValidator(min float64, max float64) goformatvalidation.Validator {
+	return &funcValidator{
+		isValid: func(value string) bool {
+			number, err := strconv.ParseFloat(value, 64)
+			return err == nil && number >= min && number <= max
+		},
+		errorMessage: fmt.Sprintf("must be a number between %v and %v", min, max),
+	}
+}
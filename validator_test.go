@@ -0,0 +1,110 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Format validators tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"github.com/sigu-399/goformatvalidation"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// Validator rejecting values longer than max bytes
+func newMaxLengthTestValidator(max int) goformatvalidation.Validator {
+	return &funcValidator{
+		isValid: func(value string) bool {
+			return len(value) <= max
+		},
+		errorMessage: `is too long`,
+	}
+}
+
+func TestChainedFormatValidators(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		query   string
+		status  int
+		message string
+	}{
+		{`valid`, `?email=bob@example.com`, http.StatusOK, `bob@example.com`},
+		{`first validator fails`, `?email=bob`, http.StatusBadRequest, `must be a valid email address`},
+		{`second validator fails`, `?email=robert.bobson@example.com`, http.StatusBadRequest, `is too long`},
+	}
+
+	queryParameters := []QueryParameter{
+		{Kind: `string`, FormatValidators: []goformatvalidation.Validator{NewEmailValidator(), newMaxLengthTestValidator(16)}},
+		{Kind: `string`, FormatValidator: NewEmailValidator(), FormatValidators: []goformatvalidation.Validator{newMaxLengthTestValidator(16)}},
+		{Kind: `string`, FormatValidator: NewAllValidator(NewEmailValidator(), newMaxLengthTestValidator(16))},
+	}
+
+	for i, queryParameter := range queryParameters {
+		s := newQueryParameterTestServer(`email`, queryParameter)
+		for _, test := range tests {
+			recorder := serveTestRequest(s, `GET`, `/items`+test.query, nil, nil)
+			if recorder.Code != test.status || !strings.Contains(recorder.Body.String(), test.message) {
+				t.Errorf(`%s #%d : got %d %q, expected %d containing %q`, test.name, i, recorder.Code, recorder.Body.String(), test.status, test.message)
+			}
+		}
+	}
+}
+
+func TestBuiltInValidators(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		validator goformatvalidation.Validator
+		value     string
+		valid     bool
+	}{
+		{`email`, NewEmailValidator(), `bob@example.com`, true},
+		{`email without domain`, NewEmailValidator(), `bob`, false},
+		{`email with display name`, NewEmailValidator(), `Bob <bob@example.com>`, false},
+		{`url`, NewURLValidator(), `https://example.com/users?id=1`, true},
+		{`url without scheme`, NewURLValidator(), `example.com/users`, false},
+		{`relative url`, NewURLValidator(), `/users`, false},
+		{`uuid`, NewUUIDValidator(), `123e4567-e89b-12d3-a456-426614174000`, true},
+		{`uuid in upper case`, NewUUIDValidator(), `123E4567-E89B-12D3-A456-426614174000`, true},
+		{`truncated uuid`, NewUUIDValidator(), `123e4567-e89b-12d3-a456`, false},
+		{`non empty`, NewNonEmptyValidator(), `bob`, true},
+		{`empty`, NewNonEmptyValidator(), ``, false},
+		{`only spaces`, NewNonEmptyValidator(), `   `, false},
+		{`in range`, NewRangeValidator(1, 10), `5.5`, true},
+		{`range lower bound`, NewRangeValidator(1, 10), `1`, true},
+		{`range upper bound`, NewRangeValidator(1, 10), `10`, true},
+		{`below range`, NewRangeValidator(1, 10), `0`, false},
+		{`not a number`, NewRangeValidator(1, 10), `five`, false},
+	}
+
+	for _, test := range tests {
+		if valid := test.validator.IsValid(test.value); valid != test.valid {
+			t.Errorf(`%s : %q valid %t, expected %t`, test.name, test.value, valid, test.valid)
+		}
+		if test.validator.GetErrorMessage() == `` {
+			t.Errorf(`%s : empty error message`, test.name)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Versioned endpoints : /v<version>/... routes, the version being given to handlers.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	const_version_path_prefix = `/v`
+)
+
+// Registers an endpoint for one API version, served on /v<version><route>, e.g. version 2 and /users on /v2/users
+// Handlers of the endpoint find the version in ResourceHandlerContext.Version, so that versions can also share handlers
+func (s *Server) NewVersionedEndpoint(version string, route string, resourceHandlers ...ResourceHandler) error {
+	return s.recordRegistrationError(s.addVersionedEndpoint(version, route, resourceHandlers))
+}
+
+func (s *Server) addVersionedEndpoint(version string, route string, resourceHandlers []ResourceHandler) error {
+
+	if version == `` || strings.Contains(version, `/`) {
+		return errors.New(fmt.Sprintf(`Invalid version '%s' for route %s`, version, route))
+	}
+
+	versionedRoute := const_version_path_prefix + version + route
+
+	err := s.addEndpointToRouter(s.router, versionedRoute, resourceHandlers...)
+	if err != nil {
+		return err
+	}
+
+	node, err := s.router.FindNodeByPattern(versionedRoute)
+	if err != nil {
+		return err
+	}
+	node.GetEndpoint().version = version
+
+	return nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Versioned endpoints tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+)
+
+// Resource handler answering the version it was reached with, prefixed
+func versionTestResourceHandler(prefix string) ResourceHandler {
+	return ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+		return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(prefix + ` ` + context.Version)}
+	})}
+}
+
+func TestVersionedEndpoints(t *testing.T) {
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{`/v1/users`, http.StatusOK, `legacy users 1`},
+		{`/v2/users`, http.StatusOK, `users 2`},
+		{`/v3/users`, http.StatusOK, `users 3`},
+		{`/v4/users`, http.StatusNotFound, `Could not find route for /v4/users`},
+		{`/users`, http.StatusOK, `users `},
+	}
+
+	s := NewServer(`/`, `:0`)
+	s.NewVersionedEndpoint(`1`, `/users`, versionTestResourceHandler(`legacy users`))
+	s.NewVersionedEndpoint(`2`, `/users`, versionTestResourceHandler(`users`))
+	s.NewVersionedEndpoint(`3`, `/users`, versionTestResourceHandler(`users`))
+	s.NewEndpoint(`/users`, versionTestResourceHandler(`users`))
+
+	for _, test := range tests {
+		recorder := serveTestRequest(s, `GET`, test.path, nil, nil)
+		if recorder.Code != test.status || recorder.Body.String() != test.body {
+			t.Errorf(`%s : got %d %q, expected %d %q`, test.path, recorder.Code, recorder.Body.String(), test.status, test.body)
+		}
+	}
+}
+
+func TestInvalidVersions(t *testing.T) {
+
+	for _, version := range []string{``, `1/beta`} {
+		s := NewServer(`/`, `:0`)
+		if err := s.NewVersionedEndpoint(version, `/users`, versionTestResourceHandler(`users`)); err == nil {
+			t.Errorf(`%q : registered`, version)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Reacting to failures writing the response body, e.g. when the client disconnects.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"net/http"
+)
+
+// Registers a function called when writing the response body of a resource handler fails
+func (s *Server) SetWriteErrorHandler(handler func(ctx *ResourceHandlerContext, err error)) {
+	s.writeErrorHandler = handler
+}
+
+// Remembers the first error writing through it
+type writeErrorRecorder struct {
+	http.ResponseWriter
+	err error
+}
+
+func (w *writeErrorRecorder) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	if err != nil && w.err == nil {
+		w.err = err
+	}
+	return n, err
+}
+
+func (w *writeErrorRecorder) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *writeErrorRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
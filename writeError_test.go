@@ -0,0 +1,95 @@
+// Copyright 2013 sigu-399 ( https://github.com/sigu-399 )
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author           sigu-399
+// author-github    https://github.com/sigu-399
+// author-mail      sigu.399@gmail.com
+//
+// repository-name  gorip
+// repository-desc  REST Server Framework - ( gorip: REST In Peace ) - Go language
+//
+// description      Response write error hook tests.
+//
+// created          16-10-2026
+
+package gorip
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var errTestClientGone = errors.New(`client disconnected`)
+
+// Response writer whose body writes fail, as when the client went away
+type failingResponseWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (w *failingResponseWriter) Write(p []byte) (int, error) {
+	return 0, errTestClientGone
+}
+
+func TestWriteErrorHandler(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		failing bool
+		stream  bool
+		err     error
+	}{
+		{`written`, false, false, nil},
+		{`write failure`, true, false, errTestClientGone},
+		{`stream write failure`, true, true, errTestClientGone},
+	}
+
+	for _, test := range tests {
+		var handledErr error
+		var handledRoute string
+
+		s := NewServer(`/`, `:0`)
+		s.SetWriteErrorHandler(func(ctx *ResourceHandlerContext, err error) {
+			handledErr = err
+			handledRoute = ctx.OriginalPath
+		})
+		s.NewEndpoint(`/reports`, ResourceHandler{Method: `GET`, ContentTypeOut: []string{`text/plain`}, Implementation: ResourceHandlerFunc(func(context *ResourceHandlerContext) ResourceHandlerResult {
+			if test.stream {
+				return ResourceHandlerResult{HttpStatus: http.StatusOK, Stream: func(stream *ResponseStream) error {
+					_, err := io.WriteString(stream, `report`)
+					return err
+				}}
+			}
+			return ResourceHandlerResult{HttpStatus: http.StatusOK, Body: bytes.NewBufferString(`report`)}
+		})})
+
+		request := httptest.NewRequest(`GET`, `/reports`, nil)
+		request.Header.Set(`Accept`, `text/plain`)
+		var writer http.ResponseWriter = httptest.NewRecorder()
+		if test.failing {
+			writer = &failingResponseWriter{ResponseRecorder: httptest.NewRecorder()}
+		}
+		s.ServeHTTP(writer, request)
+
+		if handledErr != test.err {
+			t.Errorf(`%s : handled %v, expected %v`, test.name, handledErr, test.err)
+		}
+		if test.err != nil && handledRoute != `/reports` {
+			t.Errorf(`%s : handled for %q`, test.name, handledRoute)
+		}
+	}
+}